@@ -0,0 +1,128 @@
+//
+// cluster.go
+// Copyright (C) 2021 rmelo <Ricardo Melo <rmelo@ludia.com>>
+//
+// Distributed under terms of the MIT license.
+//
+
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// formatMemberID returns the member ID in the hexadecimal format used by
+// etcdctl.
+func formatMemberID(id uint64) string {
+	return strconv.FormatUint(id, 16)
+}
+
+// parseMemberID parses a member ID written in the etcdctl hexadecimal format.
+func parseMemberID(id string) (uint64, error) {
+	v, err := strconv.ParseUint(id, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid member id %q, expected an hexadecimal value like etcdctl prints", id)
+	}
+	return v, nil
+}
+
+// findMember returns the member with the given ID, or nil when it is not part
+// of the cluster.
+func findMember(members []*etcdserverpb.Member, id uint64) *etcdserverpb.Member {
+	for _, member := range members {
+		if member.ID == id {
+			return member
+		}
+	}
+	return nil
+}
+
+// clusterLeaderID asks the configured endpoints, in order, for the current
+// leader.
+func clusterLeaderID(ctx context.Context, cli *clientv3.Client) (uint64, error) {
+	var err error
+	for _, ep := range cli.Endpoints() {
+		var resp *clientv3.StatusResponse
+		resp, err = cli.Status(ctx, ep)
+		if err == nil {
+			return resp.Leader, nil
+		}
+	}
+	return 0, fmt.Errorf("no endpoint reported the cluster leader: %v", err)
+}
+
+// memberHealthy returns true when one of the member client URLs answers a
+// status request.
+func memberHealthy(ctx context.Context, cli *clientv3.Client, member *etcdserverpb.Member) bool {
+	for _, u := range member.ClientURLs {
+		if _, err := cli.Status(ctx, u); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// checkMemberRemoval refuses to remove the current leader, the last voting
+// member, or a voting member whose removal would leave fewer healthy voters
+// than the quorum of the remaining cluster.
+func checkMemberRemoval(ctx context.Context, cli *clientv3.Client, id uint64) error {
+	resp, err := cli.MemberList(ctx)
+	if err != nil {
+		return err
+	}
+	target := findMember(resp.Members, id)
+	if target == nil || target.IsLearner {
+		return nil
+	}
+
+	leader, err := clusterLeaderID(ctx, cli)
+	if err != nil {
+		return err
+	}
+	if leader == id {
+		return fmt.Errorf("member %v is the current leader, move the leadership to another member before removing it", formatMemberID(id))
+	}
+
+	voters := 0
+	healthy := 0
+	for _, member := range resp.Members {
+		if member.ID == id || member.IsLearner {
+			continue
+		}
+		voters++
+		if memberHealthy(ctx, cli, member) {
+			healthy++
+		}
+	}
+	if voters == 0 {
+		return fmt.Errorf("member %v is the last voting member of the cluster", formatMemberID(id))
+	}
+	if quorum := voters/2 + 1; healthy < quorum {
+		return fmt.Errorf("removing member %v leaves %d healthy voting members out of %d, below the quorum of %d", formatMemberID(id), healthy, voters, quorum)
+	}
+	return nil
+}
+
+// currentRevision returns the current revision of the cluster.
+func currentRevision(ctx context.Context, cli *clientv3.Client) (int64, error) {
+	// Any key works, only the response header is used.
+	resp, err := cli.Get(ctx, "/", clientv3.WithCountOnly())
+	if err != nil {
+		return 0, err
+	}
+	return resp.Header.Revision, nil
+}
+
+// endpointClient returns a new client talking only to endpoint, for requests
+// that must reach a given member. The caller closes it.
+func (p *providerMeta) endpointClient(endpoint string) (*clientv3.Client, error) {
+	cfg := p.config
+	cfg.Endpoints = []string{endpoint}
+	cfg.AutoSyncInterval = 0
+	return clientv3.New(cfg)
+}
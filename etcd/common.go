@@ -8,7 +8,11 @@
 package etcd
 
 import (
+	"fmt"
+	"time"
+
 	uuid "github.com/satori/go.uuid"
+	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
 //uuidGenerator return random uuid that are intended to be used as unique identifiers.
@@ -29,3 +33,43 @@ func contains(s []string, str string) bool {
 
 	return false
 }
+
+// permissionRange returns the key and range end of a permission as seen by
+// etcd. The auth API is not wrapped by the client namespace, so both ends are
+// computed against the full prefix.
+func (p *providerMeta) permissionRange(key string, rangeEnd string, withPrefix bool) (string, string) {
+	fullKey := p.namespace + key
+	if withPrefix {
+		return fullKey, clientv3.GetPrefixRangeEnd(fullKey)
+	}
+	if rangeEnd == "" || p.namespace == "" {
+		return fullKey, rangeEnd
+	}
+	if rangeEnd == "\x00" {
+		// "\x00" means every key >= key, which must stop at the namespace end.
+		return fullKey, clientv3.GetPrefixRangeEnd(p.namespace)
+	}
+	return fullKey, p.namespace + rangeEnd
+}
+
+// validateDuration checks that a string attribute can be parsed by
+// time.ParseDuration and is not negative.
+func validateDuration(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%q must be a duration like 5s or 500ms, got: %v", key, v))
+	} else if d < 0 {
+		errs = append(errs, fmt.Errorf("%q must not be negative, got: %v", key, v))
+	}
+	return
+}
+
+// expandStringList converts a schema.TypeList of strings.
+func expandStringList(list []interface{}) []string {
+	result := make([]string, 0, len(list))
+	for _, v := range list {
+		result = append(result, v.(string))
+	}
+	return result
+}
@@ -0,0 +1,34 @@
+package etcd
+
+import "testing"
+
+func TestPermissionRange(t *testing.T) {
+	cases := []struct {
+		name       string
+		namespace  string
+		key        string
+		rangeEnd   string
+		withPrefix bool
+		wantKey    string
+		wantEnd    string
+	}{
+		{name: "single key", key: "/a", wantKey: "/a", wantEnd: ""},
+		{name: "range", key: "/a", rangeEnd: "/b", wantKey: "/a", wantEnd: "/b"},
+		{name: "prefix", key: "/a/", withPrefix: true, wantKey: "/a/", wantEnd: "/a0"},
+		{name: "from key", key: "/a", rangeEnd: "\x00", wantKey: "/a", wantEnd: "\x00"},
+		{name: "namespaced single key", namespace: "/ns", key: "/a", wantKey: "/ns/a", wantEnd: ""},
+		{name: "namespaced range", namespace: "/ns", key: "/a", rangeEnd: "/b", wantKey: "/ns/a", wantEnd: "/ns/b"},
+		{name: "namespaced prefix", namespace: "/ns", key: "/a/", withPrefix: true, wantKey: "/ns/a/", wantEnd: "/ns/a0"},
+		{name: "namespaced from key", namespace: "/ns", key: "/a", rangeEnd: "\x00", wantKey: "/ns/a", wantEnd: "/nt"},
+		{name: "whole namespace", namespace: "/ns", key: "", withPrefix: true, wantKey: "/ns", wantEnd: "/nt"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p := &providerMeta{namespace: c.namespace}
+			key, end := p.permissionRange(c.key, c.rangeEnd, c.withPrefix)
+			if key != c.wantKey || end != c.wantEnd {
+				t.Fatalf("expected (%q, %q), got (%q, %q)", c.wantKey, c.wantEnd, key, end)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+//
+// config.go
+// Copyright (C) 2021 rmelo <Ricardo Melo <rmelo@ludia.com>>
+//
+// Distributed under terms of the MIT license.
+//
+
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/pkg/transport"
+	"google.golang.org/grpc"
+)
+
+// defaultEndpoint is used when neither `endpoints` nor ETCD_ENDPOINT is set.
+const defaultEndpoint = "localhost:2379"
+
+// srvResolver looks up DNS SRV records. It matches net.Resolver so the
+// discovery can be pointed at another DNS server.
+type srvResolver interface {
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+}
+
+// newSRVResolver returns the resolver used for `discovery_srv`. Queries are
+// sent to server, as host:port, when it is set, and to the system resolver
+// otherwise.
+func newSRVResolver(server string) srvResolver {
+	if server == "" {
+		return net.DefaultResolver
+	}
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, server)
+		},
+	}
+}
+
+// resolveClientConfig builds the etcd client configuration from the provider
+// settings. Incomplete or contradictory settings are reported as diagnostics
+// pointing at the offending attribute instead of being ignored. resolver looks
+// up `discovery_srv`.
+func resolveClientConfig(ctx context.Context, d *schema.ResourceData, resolver srvResolver) (clientv3.Config, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var endpoints []string
+	var endpointDiags diag.Diagnostics
+
+	rawEndpoints := d.Get("endpoints").(string)
+	srvDomain := d.Get("discovery_srv").(string)
+	if srvDomain != "" {
+		if rawEndpoints != "" {
+			diags = append(diags, attributeError("discovery_srv", "Contradictory endpoint settings",
+				"'endpoints' and 'discovery_srv' are both set. Use only one of them."))
+		}
+		endpoints, endpointDiags = discoverEndpoints(ctx, resolver, srvDomain, d.Get("discovery_srv_name").(string), d.Get("tls").(bool))
+	} else {
+		endpoints, endpointDiags = resolveEndpoints(rawEndpoints)
+	}
+	diags = append(diags, endpointDiags...)
+
+	username := d.Get("username").(string)
+	password := d.Get("password").(string)
+	if username != "" && password == "" {
+		diags = append(diags, attributeError("password", "Missing etcd password",
+			fmt.Sprintf("'username' is set to %q but 'password' is empty. Set both or none of them.", username)))
+	}
+	if username == "" && password != "" {
+		diags = append(diags, attributeError("username", "Missing etcd username",
+			"'password' is set but 'username' is empty. Set both or none of them."))
+	}
+
+	useTLS := d.Get("tls").(bool)
+	caCert := d.Get("ca_cert").(string)
+	if !useTLS && caCert != "" {
+		diags = append(diags, attributeError("ca_cert", "Contradictory TLS settings",
+			"'ca_cert' is set but 'tls' is false. Enable 'tls' or remove 'ca_cert'."))
+	}
+
+	dialTimeout, _ := time.ParseDuration(d.Get("dial_timeout").(string))
+	requestTimeout, _ := time.ParseDuration(d.Get("request_timeout").(string))
+	retryBackoff, _ := time.ParseDuration(d.Get("retry_backoff").(string))
+	// Unset durations parse as 0, which keeps the client defaults.
+	autoSyncInterval, _ := time.ParseDuration(d.Get("auto_sync_interval").(string))
+	keepaliveTime, _ := time.ParseDuration(d.Get("keepalive_time").(string))
+	keepaliveTimeout, _ := time.ParseDuration(d.Get("keepalive_timeout").(string))
+	if keepaliveTimeout != 0 && keepaliveTime == 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       "Keepalive timeout ignored",
+			Detail:        "'keepalive_timeout' has no effect unless 'keepalive_time' is set.",
+			AttributePath: cty.GetAttrPath("keepalive_timeout"),
+		})
+	}
+
+	cfg := clientv3.Config{
+		Endpoints:            endpoints,
+		AutoSyncInterval:     autoSyncInterval,
+		DialTimeout:          dialTimeout,
+		DialKeepAliveTime:    keepaliveTime,
+		DialKeepAliveTimeout: keepaliveTimeout,
+		MaxCallSendMsgSize:   d.Get("max_call_send_msg_size").(int),
+		MaxCallRecvMsgSize:   d.Get("max_call_recv_msg_size").(int),
+		DialOptions: []grpc.DialOption{
+			grpc.WithChainUnaryInterceptor(retryUnaryInterceptor(requestTimeout, d.Get("max_retries").(int), retryBackoff)),
+		},
+		Username:         username,
+		Password:         password,
+		RejectOldCluster: d.Get("reject_old_cluster").(bool),
+	}
+
+	if useTLS && !diags.HasError() {
+		tlsInfo := transport.TLSInfo{
+			TrustedCAFile: caCert,
+		}
+		tlsConfig, err := tlsInfo.ClientConfig()
+		if err != nil {
+			diags = append(diags, attributeError("ca_cert", "Invalid TLS settings",
+				fmt.Sprintf("Failed loading the CA certificate %q: %v", caCert, err)))
+		}
+		cfg.TLS = tlsConfig
+	}
+
+	return cfg, diags
+}
+
+// resolveEndpoints splits the comma separated `endpoints` attribute, falling
+// back to defaultEndpoint when it is empty.
+func resolveEndpoints(raw string) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if strings.TrimSpace(raw) == "" {
+		return []string{defaultEndpoint}, diags
+	}
+	var endpoints []string
+	for i, e := range strings.Split(raw, ",") {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			diags = append(diags, attributeError("endpoints", "Invalid etcd endpoints",
+				fmt.Sprintf("Endpoint #%d in %q is blank.", i+1, raw)))
+			continue
+		}
+		endpoints = append(endpoints, e)
+	}
+
+	return endpoints, diags
+}
+
+// discoverEndpoints resolves the client endpoints published under
+// _etcd-client-ssl._tcp.<domain> (or _etcd-client._tcp.<domain> without TLS)
+// the same way `etcdctl --discovery-srv` does. serviceName is appended to the
+// service, as in `--discovery-srv-name`.
+func discoverEndpoints(ctx context.Context, resolver srvResolver, domain string, serviceName string, useTLS bool) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	service := "etcd-client"
+	if useTLS {
+		service = "etcd-client-ssl"
+	}
+	if serviceName != "" {
+		service = fmt.Sprintf("%v-%v", service, serviceName)
+	}
+
+	_, records, err := resolver.LookupSRV(ctx, service, "tcp", domain)
+	if err != nil {
+		return nil, append(diags, attributeError("discovery_srv", "Failed discovering etcd endpoints",
+			fmt.Sprintf("Failed looking up _%v._tcp.%v: %v", service, domain, err)))
+	}
+	var endpoints []string
+	for _, r := range records {
+		endpoints = append(endpoints, net.JoinHostPort(strings.TrimSuffix(r.Target, "."), fmt.Sprintf("%d", r.Port)))
+	}
+	if len(endpoints) == 0 {
+		return nil, append(diags, attributeError("discovery_srv", "Failed discovering etcd endpoints",
+			fmt.Sprintf("_%v._tcp.%v has no SRV record.", service, domain)))
+	}
+
+	return endpoints, diags
+}
+
+// checkClusterHealth calls Status on the configured endpoints and succeeds as
+// soon as one of them answers, so a misconfigured provider fails before any
+// resource is planned. Each endpoint is given at most timeout to answer.
+func checkClusterHealth(ctx context.Context, cli *clientv3.Client, timeout time.Duration) diag.Diagnostics {
+	var errs []string
+
+	for _, ep := range cli.Endpoints() {
+		if _, err := endpointStatus(ctx, cli, ep, timeout); err != nil {
+			errs = append(errs, fmt.Sprintf("%v: %v", ep, err))
+			continue
+		}
+		return nil
+	}
+
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to reach etcd",
+			Detail:   fmt.Sprintf("No endpoint answered the status request.\n%v", strings.Join(errs, "\n")),
+		},
+	}
+}
+
+// endpointStatus calls Status on endpoint, giving up after timeout when it is
+// set.
+func endpointStatus(ctx context.Context, cli *clientv3.Client, endpoint string, timeout time.Duration) (*clientv3.StatusResponse, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return cli.Status(ctx, endpoint)
+}
+
+func attributeError(attribute string, summary string, detail string) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity:      diag.Error,
+		Summary:       summary,
+		Detail:        detail,
+		AttributePath: cty.GetAttrPath(attribute),
+	}
+}
@@ -0,0 +1,156 @@
+package etcd
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// startStubDNS serves the SRV records of records, keyed by fully qualified
+// name, on a local UDP port and returns its address.
+func startStubDNS(t *testing.T, records map[string][]dnsmessage.SRVResource) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 1500)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			var query dnsmessage.Message
+			if err := query.Unpack(buf[:n]); err != nil || len(query.Questions) == 0 {
+				continue
+			}
+			question := query.Questions[0]
+			answer := dnsmessage.Message{
+				Header: dnsmessage.Header{
+					ID:            query.Header.ID,
+					Response:      true,
+					Authoritative: true,
+				},
+				Questions: []dnsmessage.Question{question},
+			}
+			srvs, ok := records[question.Name.String()]
+			if !ok {
+				answer.Header.RCode = dnsmessage.RCodeNameError
+			}
+			if question.Type == dnsmessage.TypeSRV {
+				for i := range srvs {
+					answer.Answers = append(answer.Answers, dnsmessage.Resource{
+						Header: dnsmessage.ResourceHeader{
+							Name:  question.Name,
+							Type:  dnsmessage.TypeSRV,
+							Class: dnsmessage.ClassINET,
+							TTL:   60,
+						},
+						Body: &srvs[i],
+					})
+				}
+			}
+			packed, err := answer.Pack()
+			if err != nil {
+				continue
+			}
+			conn.WriteTo(packed, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func srvRecord(target string, port uint16) dnsmessage.SRVResource {
+	return dnsmessage.SRVResource{
+		Priority: 0,
+		Weight:   10,
+		Port:     port,
+		Target:   dnsmessage.MustNewName(target),
+	}
+}
+
+func TestDiscoverEndpoints(t *testing.T) {
+	server := startStubDNS(t, map[string][]dnsmessage.SRVResource{
+		"_etcd-client-ssl._tcp.example.com.": {
+			srvRecord("etcd-1.example.com.", 2379),
+		},
+		"_etcd-client._tcp.example.com.": {
+			srvRecord("etcd-2.example.com.", 2380),
+		},
+		"_etcd-client-ssl-prod._tcp.example.com.": {
+			srvRecord("etcd-3.example.com.", 2381),
+		},
+		"_etcd-client-ssl._tcp.empty.example.com.": {},
+	})
+	resolver := newSRVResolver(server)
+
+	cases := []struct {
+		name        string
+		domain      string
+		serviceName string
+		useTLS      bool
+		endpoints   []string
+		summary     string
+	}{
+		{name: "ssl", domain: "example.com", useTLS: true, endpoints: []string{"etcd-1.example.com:2379"}},
+		{name: "non-ssl", domain: "example.com", useTLS: false, endpoints: []string{"etcd-2.example.com:2380"}},
+		{name: "service name", domain: "example.com", serviceName: "prod", useTLS: true, endpoints: []string{"etcd-3.example.com:2381"}},
+		{name: "empty answer", domain: "empty.example.com", useTLS: true, summary: "Failed discovering etcd endpoints"},
+		{name: "unknown domain", domain: "unknown.example.com", useTLS: true, summary: "Failed discovering etcd endpoints"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			endpoints, diags := discoverEndpoints(context.Background(), resolver, c.domain, c.serviceName, c.useTLS)
+			if c.summary != "" {
+				if !diags.HasError() || diags[0].Summary != c.summary {
+					t.Fatalf("expected %q error, got %v", c.summary, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if !reflect.DeepEqual(endpoints, c.endpoints) {
+				t.Fatalf("expected %v, got %v", c.endpoints, endpoints)
+			}
+		})
+	}
+}
+
+func TestResolveClientConfigDiscoveryConflict(t *testing.T) {
+	server := startStubDNS(t, map[string][]dnsmessage.SRVResource{
+		"_etcd-client._tcp.example.com.": {
+			srvRecord("etcd-1.example.com.", 2379),
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"endpoints":     "localhost:2379",
+		"discovery_srv": "example.com",
+		"tls":           false,
+	})
+	_, diags := resolveClientConfig(context.Background(), d, newSRVResolver(server))
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "Contradictory endpoint settings") {
+		t.Fatalf("expected a conflict error, got %v", diags)
+	}
+
+	d = schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"discovery_srv": "example.com",
+		"tls":           false,
+	})
+	cfg, diags := resolveClientConfig(context.Background(), d, newSRVResolver(server))
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if !reflect.DeepEqual(cfg.Endpoints, []string{"etcd-1.example.com:2379"}) {
+		t.Fatalf("unexpected endpoints %v", cfg.Endpoints)
+	}
+}
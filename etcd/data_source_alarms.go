@@ -0,0 +1,46 @@
+package etcd
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAlarms() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAlarmsRead,
+		Schema: map[string]*schema.Schema{
+			"alarms": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     alarmMemberSchema(),
+			},
+		},
+	}
+}
+
+func dataSourceAlarmsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	cli := m.(*providerMeta).client
+
+	resp, err := cli.AlarmList(ctx)
+	if err != nil {
+		return append(diag.FromErr(err), diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error reading data from etcd",
+			Detail:   "Failed calling cli.AlarmList() from dataSourceAlarmsRead()",
+		})
+	}
+
+	if err := d.Set("alarms", flattenAlarms(resp.Alarms)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// always run
+	d.SetId(uuidGenerator())
+
+	return diags
+}
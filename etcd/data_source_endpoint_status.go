@@ -0,0 +1,148 @@
+package etcd
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceEndpointStatus() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceEndpointStatusRead,
+		Schema: map[string]*schema.Schema{
+			"healthy": &schema.Schema{
+				Description: "True when every endpoint is healthy.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"leader_endpoint": &schema.Schema{
+				Description: "Endpoint served by the current leader. Empty when the leader is not one of the configured endpoints.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"endpoints": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"endpoint": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"healthy": &schema.Schema{
+							Description: "True when the endpoint answered and reported no error.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"member_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"db_size": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"db_size_in_use": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"leader_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_learner": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"raft_term": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"raft_index": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"raft_applied_index": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"errors": &schema.Schema{
+							Description: "Errors reported by the endpoint, or the error raised while reaching it.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceEndpointStatusRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	cli := m.(*providerMeta).client
+
+	healthy := true
+	leaderEndpoint := ""
+	endpoints := make([]interface{}, len(cli.Endpoints()), len(cli.Endpoints()))
+
+	for i, ep := range cli.Endpoints() {
+		entry := make(map[string]interface{})
+		entry["endpoint"] = ep
+
+		resp, err := cli.Status(ctx, ep)
+		if err != nil {
+			// An unreachable endpoint is reported, not raised.
+			entry["healthy"] = false
+			entry["errors"] = []string{err.Error()}
+			endpoints[i] = entry
+			healthy = false
+			continue
+		}
+
+		entry["healthy"] = len(resp.Errors) == 0
+		entry["member_id"] = formatMemberID(resp.Header.MemberId)
+		entry["version"] = resp.Version
+		entry["db_size"] = int(resp.DbSize)
+		entry["db_size_in_use"] = int(resp.DbSizeInUse)
+		entry["leader_id"] = formatMemberID(resp.Leader)
+		entry["is_learner"] = resp.IsLearner
+		entry["raft_term"] = int(resp.RaftTerm)
+		entry["raft_index"] = int(resp.RaftIndex)
+		entry["raft_applied_index"] = int(resp.RaftAppliedIndex)
+		entry["errors"] = resp.Errors
+		endpoints[i] = entry
+
+		if len(resp.Errors) != 0 {
+			healthy = false
+		}
+		if resp.Header.MemberId == resp.Leader && leaderEndpoint == "" {
+			leaderEndpoint = ep
+		}
+	}
+
+	if err := d.Set("endpoints", endpoints); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("healthy", healthy); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("leader_endpoint", leaderEndpoint); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// always run
+	d.SetId(uuidGenerator())
+
+	return diags
+}
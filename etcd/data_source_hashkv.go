@@ -0,0 +1,164 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceHashKV() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHashKVRead,
+		Schema: map[string]*schema.Schema{
+			"revision": &schema.Schema{
+				Description:  "Revision the hashes are computed at. The current revision is used when not set.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"fail_on_mismatch": &schema.Schema{
+				Description: "Fail the plan when the members disagree.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"consistent": &schema.Schema{
+				Description: "True when every member reports the same hash and compact revision.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"members": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"member_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"endpoint": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"hash": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"compact_revision": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceHashKVRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	cli := m.(*providerMeta).client
+
+	revision := int64(d.Get("revision").(int))
+	if revision == 0 {
+		current, err := currentRevision(ctx, cli)
+		if err != nil {
+			return append(diag.FromErr(err), diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error reading data from etcd",
+				Detail:   "Failed reading the current revision.",
+			})
+		}
+		revision = current
+	}
+
+	resp, err := cli.MemberList(ctx)
+	if err != nil {
+		return append(diag.FromErr(err), diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error reading data from etcd",
+			Detail:   "Failed calling cli.MemberList() from dataSourceHashKVRead()",
+		})
+	}
+
+	var members []interface{}
+	var hashes = make(map[uint32]bool)
+	var compactRevisions = make(map[int64]bool)
+	for _, member := range resp.Members {
+		if len(member.ClientURLs) == 0 {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Member skipped",
+				Detail:   fmt.Sprintf("Member %v has no client url, it has probably not been started.", formatMemberID(member.ID)),
+			})
+			continue
+		}
+		endpoint := member.ClientURLs[0]
+		// hashing a large keyspace can outlast request_timeout
+		hashResp, err := cli.HashKV(withLongRequest(ctx), endpoint, revision)
+		if err != nil {
+			return append(diag.FromErr(err), diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error reading data from etcd",
+				Detail:   fmt.Sprintf("Failed calling cli.HashKV() on member %v at revision %d.", formatMemberID(member.ID), revision),
+			})
+		}
+		hashes[hashResp.Hash] = true
+		compactRevisions[hashResp.CompactRevision] = true
+
+		entry := make(map[string]interface{})
+
+		entry["member_id"] = formatMemberID(member.ID)
+		entry["name"] = member.Name
+		entry["endpoint"] = endpoint
+		entry["hash"] = int(hashResp.Hash)
+		entry["compact_revision"] = int(hashResp.CompactRevision)
+
+		members = append(members, entry)
+	}
+
+	consistent := len(hashes) <= 1 && len(compactRevisions) <= 1
+	if !consistent {
+		severity := diag.Warning
+		if d.Get("fail_on_mismatch").(bool) {
+			severity = diag.Error
+		}
+		detail := fmt.Sprintf("The members report %d different hashes at revision %d.", len(hashes), revision)
+		if len(compactRevisions) > 1 {
+			detail = fmt.Sprintf("The members have been compacted at %d different revisions, their hashes at revision %d cannot be compared.", len(compactRevisions), revision)
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: severity,
+			Summary:  "Members are not consistent",
+			Detail:   detail,
+		})
+		if severity == diag.Error {
+			return diags
+		}
+	}
+
+	if err := d.Set("revision", int(revision)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("consistent", consistent); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("members", members); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// always run
+	d.SetId(uuidGenerator())
+
+	return diags
+}
@@ -3,10 +3,11 @@ package etcd
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
@@ -18,10 +19,33 @@ func dataSourceKey() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"revision": &schema.Schema{
+				Description:  "Read the key as it was at this revision. 0 means the current revision.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"default": &schema.Schema{
+				Description: "Value returned when the key does not exist. Setting it to a non-empty value allows the key to be missing, use `allow_missing` for an empty fallback.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"allow_missing": &schema.Schema{
+				Description: "Return `default`, or an empty value, instead of failing when the key does not exist.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
 			"value": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"exists": &schema.Schema{
+				Description: "True when the key exists.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
 			"last_updated": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
@@ -33,28 +57,46 @@ func dataSourceKey() *schema.Resource {
 func dataSourceKeyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	var diags diag.Diagnostics
-	var requestTimeout = 5 * time.Second
 
-	cli := m.(*clientv3.Client)
+	cli := m.(*providerMeta).client
 
-	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
 	key := fmt.Sprintf("%v", d.Get("key"))
-	resp, err := cli.Get(ctx, key)
-	cancel()
-	if err != nil {
+	var opts []clientv3.OpOption
+	if rev := d.Get("revision").(int); rev > 0 {
+		opts = append(opts, clientv3.WithRev(int64(rev)))
+	}
+	resp, err := cli.Get(ctx, key, opts...)
+	if err == rpctypes.ErrCompacted {
 		return append(diag.FromErr(err), diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Error reading data from etcd",
-			Detail:   "Failed calling cli.Get() from dataSourceKeyRead()",
+			Detail:   fmt.Sprintf("Revision %d has been compacted, the value of %v at that revision is no longer available.", d.Get("revision").(int), key),
 		})
 	}
-	if resp.Count == 0 {
+	if err != nil {
 		return append(diag.FromErr(err), diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Error reading data from etcd",
-			Detail:   "Etcd returns no answer. It is suppose to have at least one empty value.",
+			Detail:   "Failed calling cli.Get() from dataSourceKeyRead()",
 		})
 	}
+	if resp.Count == 0 {
+		// an empty default is not told apart from an unset one
+		_, hasDefault := d.GetOk("default")
+		if !hasDefault && !d.Get("allow_missing").(bool) {
+			return append(diag.FromErr(err), diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error reading data from etcd",
+				Detail:   fmt.Sprintf("The key %v does not exist. Set 'default' or 'allow_missing' if it is optional.", key),
+			})
+		}
+		d.Set("value", d.Get("default").(string))
+		d.Set("exists", false)
+		// always run
+		d.SetId(uuidGenerator())
+		return diags
+	}
+	d.Set("exists", true)
 	for _, ev := range resp.Kvs {
 		if err := d.Set("value", string(ev.Value)); err != nil {
 			return append(diag.FromErr(err), diag.Diagnostic{
@@ -0,0 +1,155 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func dataSourceKeyHistory() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKeyHistoryRead,
+		Schema: map[string]*schema.Schema{
+			"key": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"limit": &schema.Schema{
+				Description:  "Maximum number of changes returned.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"revision": &schema.Schema{
+				Description:  "Walk the history back from this revision. 0 means the current revision.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"compacted": &schema.Schema{
+				Description: "True when older changes exist but have been removed by a compaction.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"history": &schema.Schema{
+				Description: "Changes of the key, the most recent first.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"value": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"mod_revision": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"create_revision": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"version": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKeyHistoryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	cli := m.(*providerMeta).client
+
+	key := d.Get("key").(string)
+	limit := d.Get("limit").(int)
+	revision := int64(d.Get("revision").(int))
+
+	var history []interface{}
+	compacted := false
+	// every version of the key is read at the revision right before the
+	// mod_revision of the following one, until its first version is reached.
+	for len(history) < limit {
+		var opts []clientv3.OpOption
+		if revision > 0 {
+			opts = append(opts, clientv3.WithRev(revision))
+		}
+		resp, err := cli.Get(ctx, key, opts...)
+		if err == rpctypes.ErrCompacted {
+			if len(history) == 0 {
+				return append(diag.FromErr(err), diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Error reading data from etcd",
+					Detail:   fmt.Sprintf("Revision %d has been compacted, the history of %v before it is no longer available.", revision, key),
+				})
+			}
+			compacted = true
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "History has been compacted",
+				Detail:   fmt.Sprintf("Only %d changes of %v are available, the ones before revision %d have been compacted.", len(history), key, revision+1),
+			})
+			break
+		}
+		if err != nil {
+			return append(diag.FromErr(err), diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error reading data from etcd",
+				Detail:   fmt.Sprintf("Failed calling cli.Get() on %v at revision %d.", key, revision),
+			})
+		}
+		if len(resp.Kvs) == 0 {
+			// only the starting revision can miss the key, the older ones
+			// are read while it exists
+			at := revision
+			if at == 0 {
+				at = resp.Header.Revision
+			}
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Key not found",
+				Detail:   fmt.Sprintf("%v does not exist at revision %d, it has never been created or has been deleted. Set 'revision' before its deletion to read its history.", key, at),
+			})
+			break
+		}
+		kv := resp.Kvs[0]
+
+		entry := make(map[string]interface{})
+
+		entry["value"] = string(kv.Value)
+		entry["mod_revision"] = int(kv.ModRevision)
+		entry["create_revision"] = int(kv.CreateRevision)
+		entry["version"] = int(kv.Version)
+
+		history = append(history, entry)
+
+		if kv.Version == 1 {
+			break
+		}
+		revision = kv.ModRevision - 1
+	}
+
+	if err := d.Set("compacted", compacted); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("history", history); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// always run
+	d.SetId(uuidGenerator())
+
+	return diags
+}
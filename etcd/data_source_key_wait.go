@@ -0,0 +1,181 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func dataSourceKeyWait() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKeyWaitRead,
+		Schema: map[string]*schema.Schema{
+			"key": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"with_prefix": &schema.Schema{
+				Description: "Watch every key starting with `key`. The condition is met as soon as one of them matches, or for `deleted` when none is left.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"condition": &schema.Schema{
+				Description:  "Condition waited for: exists, equals, regex or deleted.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "exists",
+				ValidateFunc: validation.StringInSlice([]string{"exists", "equals", "regex", "deleted"}, false),
+			},
+			"expected": &schema.Schema{
+				Description: "Value compared with for `equals`, or regular expression matched for `regex`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"matched_key": &schema.Schema{
+				Description: "Key that met the condition.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"value": &schema.Schema{
+				Description: "Value of the key when the condition has been met, empty for `deleted`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"revision": &schema.Schema{
+				Description: "Revision the condition has been met at.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func dataSourceKeyWaitRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	cli := m.(*providerMeta).client
+
+	key := d.Get("key").(string)
+	condition := d.Get("condition").(string)
+	expected, hasExpected := d.GetOk("expected")
+	if (condition == "equals" || condition == "regex") && !hasExpected {
+		return diag.Diagnostics{attributeError("expected", "Missing expected value", fmt.Sprintf("'expected' is required by the %v condition.", condition))}
+	}
+
+	var match func(value []byte) bool
+	switch condition {
+	case "equals":
+		match = func(value []byte) bool { return string(value) == expected.(string) }
+	case "regex":
+		re, err := regexp.Compile(expected.(string))
+		if err != nil {
+			return diag.Diagnostics{attributeError("expected", "Invalid regular expression", err.Error())}
+		}
+		match = func(value []byte) bool { return re.Match(value) }
+	default:
+		match = func(value []byte) bool { return true }
+	}
+
+	var opts []clientv3.OpOption
+	if d.Get("with_prefix").(bool) {
+		opts = append(opts, clientv3.WithPrefix())
+	}
+
+	resp, err := cli.Get(ctx, key, opts...)
+	if err != nil {
+		return append(diag.FromErr(err), diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error reading data from etcd",
+			Detail:   "Failed calling cli.Get() from dataSourceKeyWaitRead()",
+		})
+	}
+
+	// remaining holds the keys still present, it is only needed by deleted
+	remaining := make(map[string]bool)
+	var matched *mvccpb.KeyValue
+	for _, kv := range resp.Kvs {
+		remaining[string(kv.Key)] = true
+		if condition != "deleted" && match(kv.Value) {
+			matched = kv
+			break
+		}
+	}
+	revision := resp.Header.Revision
+	done := matched != nil || condition == "deleted" && len(remaining) == 0
+
+	if !done {
+		watchCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		wch := cli.Watch(watchCtx, key, append(opts, clientv3.WithRev(revision+1))...)
+		for !done {
+			// the watch channel is closed once ctx times out
+			wr, ok := <-wch
+			if !ok {
+				return append(diag.FromErr(ctx.Err()), diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Condition not met",
+					Detail:   fmt.Sprintf("Timed out waiting for %v to meet the %v condition.", key, condition),
+				})
+			}
+			if err := wr.Err(); err != nil {
+				return append(diag.FromErr(err), diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Error reading data from etcd",
+					Detail:   fmt.Sprintf("Failed watching %v from revision %d.", key, revision+1),
+				})
+			}
+			for _, ev := range wr.Events {
+				revision = ev.Kv.ModRevision
+				if ev.Type == mvccpb.DELETE {
+					delete(remaining, string(ev.Kv.Key))
+					if condition == "deleted" && len(remaining) == 0 {
+						done = true
+						break
+					}
+					continue
+				}
+				remaining[string(ev.Kv.Key)] = true
+				if condition != "deleted" && match(ev.Kv.Value) {
+					matched = ev.Kv
+					done = true
+					break
+				}
+			}
+		}
+	}
+
+	matchedKey := key
+	value := ""
+	if matched != nil {
+		matchedKey = string(matched.Key)
+		value = string(matched.Value)
+		revision = matched.ModRevision
+	}
+	if err := d.Set("matched_key", matchedKey); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("value", value); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("revision", int(revision)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// always run
+	d.SetId(uuidGenerator())
+
+	return diags
+}
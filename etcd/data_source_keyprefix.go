@@ -2,11 +2,14 @@ package etcd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"time"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
@@ -15,13 +18,115 @@ type Etcd_key struct {
 	Value string
 }
 
+var (
+	sortTargets = map[string]clientv3.SortTarget{
+		"KEY":     clientv3.SortByKey,
+		"VERSION": clientv3.SortByVersion,
+		"CREATE":  clientv3.SortByCreateRevision,
+		"MOD":     clientv3.SortByModRevision,
+		"VALUE":   clientv3.SortByValue,
+	}
+	sortOrders = map[string]clientv3.SortOrder{
+		"NONE":    clientv3.SortNone,
+		"ASCEND":  clientv3.SortAscend,
+		"DESCEND": clientv3.SortDescend,
+	}
+)
+
 func dataSourceKeyPrefix() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceKeyPrefixRead,
 		Schema: map[string]*schema.Schema{
 			"prefix": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Description: "Prefix of the keys to read. It is the first key of the range when `range_end` or `from_key` is set.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"range_end": &schema.Schema{
+				Description:   "Read the keys in [prefix, range_end) instead of the keys starting with prefix.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"from_key"},
+			},
+			"from_key": &schema.Schema{
+				Description:   "Read every key greater than or equal to prefix instead of the keys starting with prefix.",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"range_end"},
+			},
+			"limit": &schema.Schema{
+				Description:  "Maximum number of entries returned. 0 means no limit.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"sort_by": &schema.Schema{
+				Description:  "Field the entries are sorted by: KEY, VERSION, CREATE, MOD or VALUE.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "KEY",
+				ValidateFunc: validation.StringInSlice([]string{"KEY", "VERSION", "CREATE", "MOD", "VALUE"}, false),
+			},
+			"sort_order": &schema.Schema{
+				Description:  "Sort order: NONE, ASCEND or DESCEND.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "NONE",
+				ValidateFunc: validation.StringInSlice([]string{"NONE", "ASCEND", "DESCEND"}, false),
+			},
+			"keys_only": &schema.Schema{
+				Description: "Only return the keys, leaving the values empty.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"count_only": &schema.Schema{
+				Description: "Only return `key_count`, leaving `entries` empty.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"revision": &schema.Schema{
+				Description:  "Read the keys as they were at this revision. 0 means the current revision.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"min_mod_revision": &schema.Schema{
+				Description:  "Only return the keys modified at or after this revision.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_mod_revision": &schema.Schema{
+				Description:  "Only return the keys modified at or before this revision.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"serializable": &schema.Schema{
+				Description: "Serve the read from the local member, without going through the leader. Faster, but may return stale data.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"strip_prefix": &schema.Schema{
+				Description: "Remove prefix from the keys used in `values` and `tree_json`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"separator": &schema.Schema{
+				Description:  "Separator used to split the keys into the levels of `tree_json`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "/",
+				ValidateFunc: validation.StringIsNotEmpty,
 			},
 			"entries": &schema.Schema{
 				Type:     schema.TypeList,
@@ -39,6 +144,29 @@ func dataSourceKeyPrefix() *schema.Resource {
 					},
 				},
 			},
+			"values": &schema.Schema{
+				Description: "Values indexed by key.",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"tree_json": &schema.Schema{
+				Description: "JSON object built by splitting the keys on `separator`, e.g. `jsondecode(data.etcd_keyprefix.app.tree_json).db.host`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"key_count": &schema.Schema{
+				Description: "Number of keys in the range, regardless of `limit`.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"more": &schema.Schema{
+				Description: "True when `limit` left keys of the range out.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
 			"last_updated": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
@@ -47,17 +175,102 @@ func dataSourceKeyPrefix() *schema.Resource {
 	}
 }
 
+// keyPrefixOptions converts the data source arguments into Get options.
+func keyPrefixOptions(d *schema.ResourceData) []clientv3.OpOption {
+	var opts []clientv3.OpOption
+
+	if d.Get("from_key").(bool) {
+		opts = append(opts, clientv3.WithFromKey())
+	} else if rangeEnd := d.Get("range_end").(string); rangeEnd != "" {
+		opts = append(opts, clientv3.WithRange(rangeEnd))
+	} else {
+		opts = append(opts, clientv3.WithPrefix())
+	}
+	if limit := d.Get("limit").(int); limit > 0 {
+		opts = append(opts, clientv3.WithLimit(int64(limit)))
+	}
+	opts = append(opts, clientv3.WithSort(sortTargets[d.Get("sort_by").(string)], sortOrders[d.Get("sort_order").(string)]))
+	if d.Get("keys_only").(bool) {
+		opts = append(opts, clientv3.WithKeysOnly())
+	}
+	if d.Get("count_only").(bool) {
+		opts = append(opts, clientv3.WithCountOnly())
+	}
+	if rev := d.Get("revision").(int); rev > 0 {
+		opts = append(opts, clientv3.WithRev(int64(rev)))
+	}
+	if rev := d.Get("min_mod_revision").(int); rev > 0 {
+		opts = append(opts, clientv3.WithMinModRev(int64(rev)))
+	}
+	if rev := d.Get("max_mod_revision").(int); rev > 0 {
+		opts = append(opts, clientv3.WithMaxModRev(int64(rev)))
+	}
+	if d.Get("serializable").(bool) {
+		opts = append(opts, clientv3.WithSerializable())
+	}
+
+	return opts
+}
+
+// buildKeyTree nests the values by splitting their keys on separator, ignoring
+// empty levels. It returns the keys dropped because they are both a value and
+// the parent of other keys, and the ones dropped because they have no level.
+func buildKeyTree(values map[string]string, separator string) (map[string]interface{}, []string, []string) {
+	var conflicts, unnamed []string
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	tree := make(map[string]interface{})
+	// original key of every value stored in the tree, by tree path
+	leafKeys := make(map[string]string)
+	for _, key := range keys {
+		var path []string
+		for _, level := range strings.Split(key, separator) {
+			if level != "" {
+				path = append(path, level)
+			}
+		}
+		if len(path) == 0 {
+			unnamed = append(unnamed, key)
+			continue
+		}
+
+		node := tree
+		for i, level := range path[:len(path)-1] {
+			child, ok := node[level].(map[string]interface{})
+			if !ok {
+				if _, isValue := node[level]; isValue {
+					conflicts = append(conflicts, leafKeys[strings.Join(path[:i+1], "\x00")])
+				}
+				child = make(map[string]interface{})
+				node[level] = child
+			}
+			node = child
+		}
+		leaf := path[len(path)-1]
+		if _, isBranch := node[leaf].(map[string]interface{}); isBranch {
+			conflicts = append(conflicts, key)
+			continue
+		}
+		node[leaf] = values[key]
+		leafKeys[strings.Join(path, "\x00")] = key
+	}
+
+	return tree, conflicts, unnamed
+}
+
 func dataSourceKeyPrefixRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	var diags diag.Diagnostics
-	var requestTimeout = 5 * time.Second
 
-	cli := m.(*clientv3.Client)
+	cli := m.(*providerMeta).client
 
-	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
 	prefix := fmt.Sprintf("%v", d.Get("prefix"))
-	resp, err := cli.Get(ctx, prefix, clientv3.WithPrefix())
-	cancel()
+	resp, err := cli.Get(ctx, prefix, keyPrefixOptions(d)...)
 	if err != nil {
 		return append(diag.FromErr(err), diag.Diagnostic{
 			Severity: diag.Error,
@@ -65,36 +278,64 @@ func dataSourceKeyPrefixRead(ctx context.Context, d *schema.ResourceData, m inte
 			Detail:   "Failed calling cli.Get() from dataSourceKeyRead()",
 		})
 	}
-	if resp.Count == 0 {
-		return append(diag.FromErr(err), diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Error reading data from etcd",
-			Detail:   "Etcd returns no answer. It is suppose to have at least one empty value.",
-		})
-	}
 
 	entries := make([]interface{}, len(resp.Kvs), len(resp.Kvs))
+	values := make(map[string]string)
 
 	for i, ev := range resp.Kvs {
-		if ev == nil {
-			return append(diag.FromErr(err), diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Error reading data from etcd",
-				Detail:   "Etcd prefix returns no answer. It is suppose to have at least one empty value.",
-			})
-		}
-
 		entry := make(map[string]interface{})
 
 		entry["key"] = string(ev.Key)
 		entry["value"] = string(ev.Value)
 
 		entries[i] = entry
+
+		key := string(ev.Key)
+		if d.Get("strip_prefix").(bool) {
+			key = strings.TrimPrefix(key, prefix)
+		}
+		values[key] = string(ev.Value)
+	}
+
+	separator := d.Get("separator").(string)
+	tree, conflicts, unnamed := buildKeyTree(values, separator)
+	for _, key := range conflicts {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Key left out of tree_json",
+			Detail:   fmt.Sprintf("The key %q has a value and child keys, only its children are kept in tree_json.", key),
+		})
+	}
+	for _, key := range unnamed {
+		if d.Get("strip_prefix").(bool) {
+			key = prefix + key
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Key left out of tree_json",
+			Detail:   fmt.Sprintf("The key %q has no level left once split on %q, its value has no name in tree_json.", key, separator),
+		})
+	}
+	treeJSON, err := json.Marshal(tree)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	if err := d.Set("entries", entries); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := d.Set("values", values); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tree_json", string(treeJSON)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("key_count", int(resp.Count)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("more", resp.More); err != nil {
+		return diag.FromErr(err)
+	}
 
 	// always run
 	d.SetId(uuidGenerator())
@@ -0,0 +1,91 @@
+package etcd
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestBuildKeyTree(t *testing.T) {
+	cases := []struct {
+		name      string
+		values    map[string]string
+		separator string
+		tree      map[string]interface{}
+		conflicts []string
+		unnamed   []string
+	}{
+		{
+			name:      "nested",
+			values:    map[string]string{"db/host": "h", "db/port": "5432", "name": "app"},
+			separator: "/",
+			tree: map[string]interface{}{
+				"db":   map[string]interface{}{"host": "h", "port": "5432"},
+				"name": "app",
+			},
+		},
+		{
+			name:      "empty levels",
+			values:    map[string]string{"/db//host/": "h"},
+			separator: "/",
+			tree: map[string]interface{}{
+				"db": map[string]interface{}{"host": "h"},
+			},
+		},
+		{
+			name:      "custom separator",
+			values:    map[string]string{"db.host": "h"},
+			separator: ".",
+			tree: map[string]interface{}{
+				"db": map[string]interface{}{"host": "h"},
+			},
+		},
+		{
+			name:      "value and parent",
+			values:    map[string]string{"db": "x", "db/host": "h"},
+			separator: "/",
+			tree: map[string]interface{}{
+				"db": map[string]interface{}{"host": "h"},
+			},
+			conflicts: []string{"db"},
+		},
+		{
+			name:      "parent then value",
+			values:    map[string]string{"a/b/c": "1", "a/b": "2"},
+			separator: "/",
+			tree: map[string]interface{}{
+				"a": map[string]interface{}{"b": map[string]interface{}{"c": "1"}},
+			},
+			conflicts: []string{"a/b"},
+		},
+		{
+			name:      "only separators",
+			values:    map[string]string{"/": "root"},
+			separator: "/",
+			tree:      map[string]interface{}{},
+			unnamed:   []string{"/"},
+		},
+		{
+			name:      "stripped prefix",
+			values:    map[string]string{"": "root", "db": "x"},
+			separator: "/",
+			tree:      map[string]interface{}{"db": "x"},
+			unnamed:   []string{""},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tree, conflicts, unnamed := buildKeyTree(c.values, c.separator)
+			if !reflect.DeepEqual(tree, c.tree) {
+				t.Fatalf("expected tree %v, got %v", c.tree, tree)
+			}
+			sort.Strings(conflicts)
+			if len(conflicts) != len(c.conflicts) || len(c.conflicts) > 0 && !reflect.DeepEqual(conflicts, c.conflicts) {
+				t.Fatalf("expected conflicts %q, got %q", c.conflicts, conflicts)
+			}
+			if len(unnamed) != len(c.unnamed) || len(c.unnamed) > 0 && !reflect.DeepEqual(unnamed, c.unnamed) {
+				t.Fatalf("expected unnamed keys %q, got %q", c.unnamed, unnamed)
+			}
+		})
+	}
+}
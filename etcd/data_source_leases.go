@@ -0,0 +1,129 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func dataSourceLeases() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceLeasesRead,
+		Schema: map[string]*schema.Schema{
+			"key_prefix": &schema.Schema{
+				Description: "Only return the leases with at least one attached key starting with this prefix.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"leases": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Description: "Lease ID in hexadecimal, as printed by etcdctl.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"granted_ttl": &schema.Schema{
+							Description: "TTL in seconds the lease has been granted with.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"ttl": &schema.Schema{
+							Description: "Remaining TTL in seconds.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"keys": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceLeasesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	cli := m.(*providerMeta).client
+
+	keyPrefix, filter := d.GetOk("key_prefix")
+	namespaced := m.(*providerMeta).namespace != ""
+
+	resp, err := cli.Leases(ctx)
+	if err != nil {
+		return append(diag.FromErr(err), diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error reading data from etcd",
+			Detail:   "Failed calling cli.Leases() from dataSourceLeasesRead()",
+		})
+	}
+
+	leases := make([]interface{}, 0, len(resp.Leases))
+	for _, lease := range resp.Leases {
+		ttlResp, err := cli.TimeToLive(ctx, lease.ID, clientv3.WithAttachedKeys())
+		if err == rpctypes.ErrLeaseNotFound {
+			continue
+		}
+		if err != nil {
+			return append(diag.FromErr(err), diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error reading data from etcd",
+				Detail:   fmt.Sprintf("Failed calling cli.TimeToLive() on lease %x.", int64(lease.ID)),
+			})
+		}
+		// the lease expired since it has been listed
+		if ttlResp.TTL == -1 {
+			continue
+		}
+
+		// leases are not namespaced, one without keys in the namespace
+		// may belong to another tenant
+		if namespaced && len(ttlResp.Keys) == 0 {
+			continue
+		}
+
+		keys := make([]string, 0, len(ttlResp.Keys))
+		matched := !filter
+		for _, key := range ttlResp.Keys {
+			keys = append(keys, string(key))
+			if filter && strings.HasPrefix(string(key), keyPrefix.(string)) {
+				matched = true
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		entry := make(map[string]interface{})
+
+		entry["id"] = fmt.Sprintf("%x", int64(lease.ID))
+		entry["granted_ttl"] = int(ttlResp.GrantedTTL)
+		entry["ttl"] = int(ttlResp.TTL)
+		entry["keys"] = keys
+
+		leases = append(leases, entry)
+	}
+
+	if err := d.Set("leases", leases); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// always run
+	d.SetId(uuidGenerator())
+
+	return diags
+}
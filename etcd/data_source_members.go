@@ -0,0 +1,97 @@
+package etcd
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceMembers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMembersRead,
+		Schema: map[string]*schema.Schema{
+			"cluster_id": &schema.Schema{
+				Description: "Cluster ID, in the hexadecimal format used by etcdctl.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"members": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"peer_urls": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"client_urls": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"is_learner": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceMembersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	cli := m.(*providerMeta).client
+
+	resp, err := cli.MemberList(ctx)
+	if err != nil {
+		return append(diag.FromErr(err), diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error reading data from etcd",
+			Detail:   "Failed calling cli.MemberList() from dataSourceMembersRead()",
+		})
+	}
+
+	members := make([]interface{}, len(resp.Members), len(resp.Members))
+
+	for i, member := range resp.Members {
+		entry := make(map[string]interface{})
+
+		entry["id"] = formatMemberID(member.ID)
+		entry["name"] = member.Name
+		entry["peer_urls"] = member.PeerURLs
+		entry["client_urls"] = member.ClientURLs
+		entry["is_learner"] = member.IsLearner
+
+		members[i] = entry
+	}
+
+	if err := d.Set("cluster_id", formatMemberID(resp.Header.ClusterId)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("members", members); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// always run
+	d.SetId(uuidGenerator())
+
+	return diags
+}
@@ -0,0 +1,236 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// progressInterval is how often a progress notification is requested while
+// replaying the history, so a watch without any matching event still learns
+// it has reached the current revision.
+const progressInterval = time.Second
+
+// supportsProgressNotify tells whether an etcd server version only answers
+// progress requests once the watches have caught up. Older servers answer
+// right away with the store revision while events are still being replayed.
+func supportsProgressNotify(version string) bool {
+	var major, minor, patch int
+	if _, err := fmt.Sscanf(version, "%d.%d.%d", &major, &minor, &patch); err != nil {
+		return false
+	}
+	switch {
+	case major != 3:
+		return major > 3
+	case minor == 4:
+		return patch >= 25
+	case minor == 5:
+		return patch >= 8
+	default:
+		return minor > 5
+	}
+}
+
+// checkProgressNotify fails unless every endpoint runs a server version
+// supported by supportsProgressNotify.
+func checkProgressNotify(ctx context.Context, cli *clientv3.Client) diag.Diagnostics {
+	for _, ep := range cli.Endpoints() {
+		resp, err := endpointStatus(ctx, cli, ep, 0)
+		if err != nil {
+			return append(diag.FromErr(err), diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error reading data from etcd",
+				Detail:   fmt.Sprintf("Failed reading the version of %v.", ep),
+			})
+		}
+		if !supportsProgressNotify(resp.Version) {
+			return diag.Diagnostics{diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unsupported etcd version",
+				Detail:   fmt.Sprintf("%v runs etcd %v. Listing changes requires etcd 3.4.25, 3.5.8 or later, whose progress notifications guarantee no event is missed.", ep, resp.Version),
+			}}
+		}
+	}
+	return nil
+}
+
+func dataSourcePrefixChanges() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePrefixChangesRead,
+		Schema: map[string]*schema.Schema{
+			"prefix": &schema.Schema{
+				Description: "Prefix of the keys to audit.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"since_revision": &schema.Schema{
+				Description:  "First revision included in the changes.",
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"revision": &schema.Schema{
+				Description: "Revision of the cluster when the changes have been read, the last one included.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"events": &schema.Schema{
+				Description: "Changes in revision order.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": &schema.Schema{
+							Description: "PUT or DELETE.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"key": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"prev_value": &schema.Schema{
+							Description: "Value before the change, empty when the key has been created.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"revision": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePrefixChangesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	cli := m.(*providerMeta).client
+
+	prefix := d.Get("prefix").(string)
+	since := int64(d.Get("since_revision").(int))
+
+	current, err := currentRevision(ctx, cli)
+	if err != nil {
+		return append(diag.FromErr(err), diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error reading data from etcd",
+			Detail:   "Failed reading the current revision.",
+		})
+	}
+
+	if diags := checkProgressNotify(ctx, cli); diags.HasError() {
+		return diags
+	}
+
+	events := make([]interface{}, 0)
+	if since <= current {
+		events, diags = watchChanges(ctx, cli, prefix, since, current)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if err := d.Set("revision", int(current)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("events", events); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// always run
+	d.SetId(uuidGenerator())
+
+	return diags
+}
+
+// watchChanges replays the events of the keys under prefix from revision since
+// up to revision until.
+func watchChanges(ctx context.Context, cli *clientv3.Client, prefix string, since int64, until int64) ([]interface{}, diag.Diagnostics) {
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	wch := cli.Watch(watchCtx, prefix, clientv3.WithPrefix(), clientv3.WithRev(since), clientv3.WithPrevKV())
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+
+	events := make([]interface{}, 0)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, append(diag.FromErr(ctx.Err()), diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error reading data from etcd",
+				Detail:   fmt.Sprintf("Timed out replaying the changes of %v up to revision %d.", prefix, until),
+			})
+		case <-ticker.C:
+			// ignored by the server until the watch has caught up, which
+			// checkProgressNotify made sure of
+			cli.RequestProgress(watchCtx)
+		case wr, ok := <-wch:
+			if !ok {
+				return nil, diag.Diagnostics{diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Error reading data from etcd",
+					Detail:   fmt.Sprintf("The watch on %v has been closed before reaching revision %d.", prefix, until),
+				}}
+			}
+			if wr.CompactRevision != 0 {
+				return nil, append(diag.FromErr(wr.Err()), diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "History has been compacted",
+					Detail:   fmt.Sprintf("The changes before revision %d are no longer available, 'since_revision' must be at least %d.", wr.CompactRevision, wr.CompactRevision),
+				})
+			}
+			if err := wr.Err(); err != nil {
+				return nil, append(diag.FromErr(err), diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Error reading data from etcd",
+					Detail:   fmt.Sprintf("Failed watching %v from revision %d.", prefix, since),
+				})
+			}
+			for _, ev := range wr.Events {
+				if ev.Kv.ModRevision > until {
+					return events, nil
+				}
+				entry := make(map[string]interface{})
+
+				entry["type"] = ev.Type.String()
+				entry["key"] = string(ev.Kv.Key)
+				entry["value"] = ""
+				if ev.Type == mvccpb.PUT {
+					entry["value"] = string(ev.Kv.Value)
+				}
+				entry["prev_value"] = ""
+				if ev.PrevKv != nil {
+					entry["prev_value"] = string(ev.PrevKv.Value)
+				}
+				entry["revision"] = int(ev.Kv.ModRevision)
+
+				events = append(events, entry)
+			}
+			// a progress notification is only sent once every event up to
+			// its revision has been delivered
+			if wr.IsProgressNotify() && wr.Header.Revision >= until {
+				return events, nil
+			}
+			if n := len(wr.Events); n > 0 && wr.Events[n-1].Kv.ModRevision == until {
+				return events, nil
+			}
+		}
+	}
+}
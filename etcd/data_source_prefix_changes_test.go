@@ -0,0 +1,23 @@
+package etcd
+
+import "testing"
+
+func TestSupportsProgressNotify(t *testing.T) {
+	for version, supported := range map[string]bool{
+		"3.3.27":  false,
+		"3.4.24":  false,
+		"3.4.25":  true,
+		"3.5.0":   false,
+		"3.5.7":   false,
+		"3.5.8":   true,
+		"3.5.15":  true,
+		"3.6.0":   true,
+		"4.0.0":   true,
+		"2.3.8":   false,
+		"unknown": false,
+	} {
+		if got := supportsProgressNotify(version); got != supported {
+			t.Errorf("supportsProgressNotify(%q) = %v, want %v", version, got, supported)
+		}
+	}
+}
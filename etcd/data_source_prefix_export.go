@@ -0,0 +1,270 @@
+package etcd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// exportRenderers renders sorted names and their values into each supported
+// format.
+var exportRenderers = map[string]func(names []string, values map[string]string) string{
+	"json":       renderJSON,
+	"yaml":       renderYAML,
+	"dotenv":     renderDotenv,
+	"properties": renderProperties,
+	"toml":       renderTOML,
+}
+
+// dotenvName matches the variable names accepted by dotenv files and shells.
+var dotenvName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func dataSourcePrefixExport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePrefixExportRead,
+		Schema: map[string]*schema.Schema{
+			"prefix": &schema.Schema{
+				Description: "Prefix of the keys to export.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"format": &schema.Schema{
+				Description:  "Output format: json, yaml, dotenv, properties or toml.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"json", "yaml", "dotenv", "properties", "toml"}, false),
+			},
+			"strip_prefix": &schema.Schema{
+				Description: "Remove prefix from the keys.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"trim_separator": &schema.Schema{
+				Description: "Remove the leading and trailing `/` of the keys.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"replace_separator": &schema.Schema{
+				Description: "Replace every `/` of the keys with this string, e.g. `_`. The keys are kept as they are when not set.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"uppercase": &schema.Schema{
+				Description: "Convert the keys to upper case.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"rendered": &schema.Schema{
+				Description: "Keys and values rendered in `format`, sorted by name.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourcePrefixExportRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	cli := m.(*providerMeta).client
+
+	prefix := d.Get("prefix").(string)
+	resp, err := cli.Get(ctx, prefix, clientv3.WithPrefix())
+	if err != nil {
+		return append(diag.FromErr(err), diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error reading data from etcd",
+			Detail:   "Failed calling cli.Get() from dataSourcePrefixExportRead()",
+		})
+	}
+
+	format := d.Get("format").(string)
+	values := make(map[string]string)
+	keys := make(map[string]string)
+	for _, ev := range resp.Kvs {
+		name := exportName(d, prefix, string(ev.Key))
+		if name == "" {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Empty export name",
+				Detail:   fmt.Sprintf("The key %q is exported with an empty name. Use a prefix that ends before the last level of the keys, or set 'strip_prefix' to false.", string(ev.Key)),
+			})
+		}
+		if format == "dotenv" && !dotenvName.MatchString(name) {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Invalid dotenv name",
+				Detail:   fmt.Sprintf("The key %q is exported as %q, which is not a valid dotenv name. Names must match [A-Za-z_][A-Za-z0-9_]*, e.g. set 'replace_separator' to \"_\".", string(ev.Key), name),
+			})
+		}
+		if other, ok := keys[name]; ok {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Duplicated export name",
+				Detail:   fmt.Sprintf("The keys %q and %q are both exported as %q.", other, string(ev.Key), name),
+			})
+		}
+		keys[name] = string(ev.Key)
+		values[name] = string(ev.Value)
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if err := d.Set("rendered", exportRenderers[format](names, values)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// always run
+	d.SetId(uuidGenerator())
+
+	return diags
+}
+
+// exportName applies the key to name transformation rules.
+func exportName(d *schema.ResourceData, prefix string, key string) string {
+	name := key
+	if d.Get("strip_prefix").(bool) {
+		name = strings.TrimPrefix(name, prefix)
+	}
+	if d.Get("trim_separator").(bool) {
+		name = strings.Trim(name, "/")
+	}
+	if replacement, ok := d.GetOk("replace_separator"); ok {
+		name = strings.ReplaceAll(name, "/", replacement.(string))
+	}
+	if d.Get("uppercase").(bool) {
+		name = strings.ToUpper(name)
+	}
+	return name
+}
+
+// quoteString returns s as a JSON string, which is also a valid YAML and TOML
+// basic string.
+func quoteString(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func renderJSON(names []string, values map[string]string) string {
+	var b strings.Builder
+
+	if len(names) == 0 {
+		return "{}\n"
+	}
+	b.WriteString("{\n")
+	for i, name := range names {
+		fmt.Fprintf(&b, "  %v: %v", quoteString(name), quoteString(values[name]))
+		if i < len(names)-1 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+func renderYAML(names []string, values map[string]string) string {
+	var b strings.Builder
+
+	if len(names) == 0 {
+		return "{}\n"
+	}
+	for _, name := range names {
+		fmt.Fprintf(&b, "%v: %v\n", quoteString(name), quoteString(values[name]))
+	}
+
+	return b.String()
+}
+
+func renderTOML(names []string, values map[string]string) string {
+	var b strings.Builder
+
+	for _, name := range names {
+		fmt.Fprintf(&b, "%v = %v\n", quoteString(name), quoteString(values[name]))
+	}
+
+	return b.String()
+}
+
+func renderDotenv(names []string, values map[string]string) string {
+	var b strings.Builder
+
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "\n", `\n`, "\r", `\r`)
+	for _, name := range names {
+		fmt.Fprintf(&b, "%v=\"%v\"\n", name, escaper.Replace(values[name]))
+	}
+
+	return b.String()
+}
+
+func renderProperties(names []string, values map[string]string) string {
+	var b strings.Builder
+
+	for _, name := range names {
+		fmt.Fprintf(&b, "%v=%v\n", escapeProperty(name, true), escapeProperty(values[name], false))
+	}
+
+	return b.String()
+}
+
+// escapeProperty escapes s for a Java properties file. Keys also escape the
+// spaces and the key/value separators.
+func escapeProperty(s string, isKey bool) string {
+	var b strings.Builder
+
+	for i, r := range s {
+		switch {
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '\f':
+			b.WriteString(`\f`)
+		case r == ' ' && (isKey || i == 0):
+			b.WriteString(`\ `)
+		case (r == '=' || r == ':') && isKey:
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case (r == '#' || r == '!') && i == 0:
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r > 0x7e:
+			if r > 0xffff {
+				// characters outside the BMP are written as a surrogate pair
+				r -= 0x10000
+				fmt.Fprintf(&b, `\u%04x\u%04x`, 0xd800+(r>>10), 0xdc00+(r&0x3ff))
+			} else {
+				fmt.Fprintf(&b, `\u%04x`, r)
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
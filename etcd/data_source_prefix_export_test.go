@@ -0,0 +1,70 @@
+package etcd
+
+import "testing"
+
+func TestEscapeProperty(t *testing.T) {
+	cases := []struct {
+		in    string
+		isKey bool
+		want  string
+	}{
+		{in: "plain", want: "plain"},
+		{in: "a b", isKey: true, want: `a\ b`},
+		{in: "a b", want: "a b"},
+		{in: " lead", want: `\ lead`},
+		{in: "k=v:w", isKey: true, want: `k\=v\:w`},
+		{in: "k=v:w", want: "k=v:w"},
+		{in: "#comment", want: `\#comment`},
+		{in: "a#b", want: "a#b"},
+		{in: "line\nbreak\t\\", want: `line\nbreak\t\\`},
+		{in: "é", want: `\u00e9`},
+		{in: "😀", want: `\ud83d\ude00`},
+	}
+	for _, c := range cases {
+		if got := escapeProperty(c.in, c.isKey); got != c.want {
+			t.Errorf("escapeProperty(%q, %v) = %q, want %q", c.in, c.isKey, got, c.want)
+		}
+	}
+}
+
+func TestExportRenderers(t *testing.T) {
+	names := []string{"DB_HOST", "MOTD"}
+	values := map[string]string{
+		"DB_HOST": "db.example.com",
+		"MOTD":    "say \"hi\"\n$HOME",
+	}
+	cases := map[string]string{
+		"json":       "{\n  \"DB_HOST\": \"db.example.com\",\n  \"MOTD\": \"say \\\"hi\\\"\\n$HOME\"\n}\n",
+		"yaml":       "\"DB_HOST\": \"db.example.com\"\n\"MOTD\": \"say \\\"hi\\\"\\n$HOME\"\n",
+		"toml":       "\"DB_HOST\" = \"db.example.com\"\n\"MOTD\" = \"say \\\"hi\\\"\\n$HOME\"\n",
+		"dotenv":     "DB_HOST=\"db.example.com\"\nMOTD=\"say \\\"hi\\\"\\n\\$HOME\"\n",
+		"properties": "DB_HOST=db.example.com\nMOTD=say \"hi\"\\n$HOME\n",
+	}
+	for format, want := range cases {
+		if got := exportRenderers[format](names, values); got != want {
+			t.Errorf("%v rendered %q, want %q", format, got, want)
+		}
+	}
+
+	for _, format := range []string{"json", "yaml"} {
+		if got := exportRenderers[format](nil, nil); got != "{}\n" {
+			t.Errorf("%v rendered %q for no key, want %q", format, got, "{}\n")
+		}
+	}
+}
+
+func TestDotenvName(t *testing.T) {
+	for name, valid := range map[string]bool{
+		"DB_HOST": true,
+		"_x1":     true,
+		"db/host": false,
+		"1ABC":    false,
+		"-":       false,
+		".":       false,
+		"":        false,
+	} {
+		if dotenvName.MatchString(name) != valid {
+			t.Errorf("dotenvName.MatchString(%q) = %v, want %v", name, !valid, valid)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func dataSourcePrefixStats() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePrefixStatsRead,
+		Schema: map[string]*schema.Schema{
+			"prefix": &schema.Schema{
+				Description: "Prefix of the keys to measure.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"page_size": &schema.Schema{
+				Description:  "Number of keys read per request.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1000,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"revision": &schema.Schema{
+				Description: "Revision every page has been read at.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"key_count": &schema.Schema{
+				Description: "Number of keys under the prefix.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"total_value_bytes": &schema.Schema{
+				Description: "Sum of the value sizes in bytes.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"largest_key": &schema.Schema{
+				Description: "Key holding the largest value.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"largest_value_bytes": &schema.Schema{
+				Description: "Size in bytes of the largest value.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"oldest_create_revision": &schema.Schema{
+				Description: "Lowest create_revision of the keys.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"newest_create_revision": &schema.Schema{
+				Description: "Highest create_revision of the keys.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"lease_attached_keys": &schema.Schema{
+				Description: "Number of keys attached to a lease.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourcePrefixStatsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	cli := m.(*providerMeta).client
+
+	prefix := d.Get("prefix").(string)
+	pageSize := int64(d.Get("page_size").(int))
+
+	countResp, err := cli.Get(ctx, prefix, clientv3.WithPrefix(), clientv3.WithCountOnly())
+	if err != nil {
+		return append(diag.FromErr(err), diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error reading data from etcd",
+			Detail:   "Failed calling cli.Get() from dataSourcePrefixStatsRead()",
+		})
+	}
+	// every page is read at the revision of the count so they all agree
+	revision := countResp.Header.Revision
+
+	var totalBytes, largestBytes, oldest, newest, leased int64
+	largestKey := ""
+	rangeEnd := clientv3.GetPrefixRangeEnd(prefix)
+	start := prefix
+	if start == "" {
+		start = "\x00"
+	}
+	for {
+		resp, err := cli.Get(ctx, start, clientv3.WithRange(rangeEnd), clientv3.WithLimit(pageSize), clientv3.WithRev(revision))
+		if err != nil {
+			return append(diag.FromErr(err), diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error reading data from etcd",
+				Detail:   fmt.Sprintf("Failed reading the keys of %v from %v at revision %d.", prefix, start, revision),
+			})
+		}
+		for _, kv := range resp.Kvs {
+			size := int64(len(kv.Value))
+			totalBytes += size
+			if largestKey == "" || size > largestBytes {
+				largestKey = string(kv.Key)
+				largestBytes = size
+			}
+			if oldest == 0 || kv.CreateRevision < oldest {
+				oldest = kv.CreateRevision
+			}
+			if kv.CreateRevision > newest {
+				newest = kv.CreateRevision
+			}
+			if kv.Lease != 0 {
+				leased++
+			}
+		}
+		if !resp.More || len(resp.Kvs) == 0 {
+			break
+		}
+		start = string(resp.Kvs[len(resp.Kvs)-1].Key) + "\x00"
+	}
+
+	if err := d.Set("revision", int(revision)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("key_count", int(countResp.Count)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("total_value_bytes", int(totalBytes)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("largest_key", largestKey); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("largest_value_bytes", int(largestBytes)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("oldest_create_revision", int(oldest)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("newest_create_revision", int(newest)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("lease_attached_keys", int(leased)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// always run
+	d.SetId(uuidGenerator())
+
+	return diags
+}
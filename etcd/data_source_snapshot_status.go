@@ -0,0 +1,65 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSnapshotStatus() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSnapshotStatusRead,
+		Schema: map[string]*schema.Schema{
+			"path": &schema.Schema{
+				Description: "Local snapshot file to inspect.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"hash": &schema.Schema{
+				Description: "crc32 hash of the snapshot content, as printed by `etcdutl snapshot status`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"revision": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"total_keys": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"total_size": &schema.Schema{
+				Description: "Size of the db in bytes.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceSnapshotStatusRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	path := d.Get("path").(string)
+	status, err := readSnapshotStatus(path)
+	if err != nil {
+		return append(diag.FromErr(err), diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error reading snapshot",
+			Detail:   fmt.Sprintf("Failed reading the status of snapshot %v.", path),
+		})
+	}
+
+	d.Set("hash", fmt.Sprintf("%x", status.Hash))
+	d.Set("revision", int(status.Revision))
+	d.Set("total_keys", status.TotalKey)
+	d.Set("total_size", int(status.TotalSize))
+
+	// always run
+	d.SetId(uuidGenerator())
+
+	return diags
+}
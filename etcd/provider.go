@@ -9,15 +9,42 @@ package etcd
 
 import (
 	"context"
+	"fmt"
 	"strings"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	clientv3 "go.etcd.io/etcd/client/v3"
-	"go.etcd.io/etcd/pkg/transport"
+	"go.etcd.io/etcd/client/v3/namespace"
 )
 
+// providerMeta is the value returned by providerConfigure and handed to every
+// resource and data source.
+type providerMeta struct {
+	client *clientv3.Client
+	// config is the configuration client has been created with.
+	config clientv3.Config
+	// namespace is prepended to every key. It is empty when the provider
+	// works on the whole keyspace.
+	namespace string
+}
+
+// newProviderMeta wraps the client KV, Watcher and Lease so every key used by
+// the resources is relative to keyNamespace.
+func newProviderMeta(c *clientv3.Client, cfg clientv3.Config, keyNamespace string) *providerMeta {
+	if keyNamespace != "" {
+		c.KV = namespace.NewKV(c.KV, keyNamespace)
+		c.Watcher = namespace.NewWatcher(c.Watcher, keyNamespace)
+		c.Lease = namespace.NewLease(c.Lease, keyNamespace)
+	}
+	return &providerMeta{
+		client:    c,
+		config:    cfg,
+		namespace: keyNamespace,
+	}
+}
+
 // Provider -
 func Provider() *schema.Provider {
 	return &schema.Provider{
@@ -39,6 +66,24 @@ func Provider() *schema.Provider {
 				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("ETCD_ENDPOINT", nil),
 			},
+			"discovery_srv": &schema.Schema{
+				Description: "Domain used to discover the endpoints through DNS SRV records, as `etcdctl --discovery-srv`. Conflicts with `endpoints`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ETCD_DISCOVERY_SRV", ""),
+			},
+			"discovery_srv_name": &schema.Schema{
+				Description: "Suffix appended to the SRV service name, as `etcdctl --discovery-srv-name`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ETCD_DISCOVERY_SRV_NAME", ""),
+			},
+			"discovery_dns_server": &schema.Schema{
+				Description: "DNS server, as `host:port`, queried for `discovery_srv` instead of the system resolver.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ETCD_DISCOVERY_DNS_SERVER", ""),
+			},
 			"tls": &schema.Schema{
 				Type:        schema.TypeBool,
 				DefaultFunc: schema.EnvDefaultFunc("ETCD_TLS", true),
@@ -51,78 +96,131 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"namespace": &schema.Schema{
+				Description: "Prefix prepended to every key managed by this provider.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ETCD_NAMESPACE", ""),
+			},
+			"dial_timeout": &schema.Schema{
+				Description:  "Timeout for establishing a connection to etcd, e.g. `5s`. It also bounds the health check of each endpoint when the provider is configured.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ETCD_DIAL_TIMEOUT", "5s"),
+				ValidateFunc: validateDuration,
+			},
+			"request_timeout": &schema.Schema{
+				Description:  "Timeout of every request sent to etcd, retries included, e.g. `5s`. Defragment, physical compaction and hashkv requests are only bounded by the resource timeouts.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ETCD_REQUEST_TIMEOUT", "5s"),
+				ValidateFunc: validateDuration,
+			},
+			"max_retries": &schema.Schema{
+				Description:  "Number of times a read-only request is retried when etcd is unavailable. Requests that modify etcd are sent once.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ETCD_MAX_RETRIES", 3),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_backoff": &schema.Schema{
+				Description:  "Wait before the first retry, doubled on every following one, e.g. `500ms`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ETCD_RETRY_BACKOFF", "500ms"),
+				ValidateFunc: validateDuration,
+			},
+			"auto_sync_interval": &schema.Schema{
+				Description:  "Interval used to refresh the endpoints with the cluster members, e.g. `1m`. Disabled when not set.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ETCD_AUTO_SYNC_INTERVAL", nil),
+				ValidateFunc: validateDuration,
+			},
+			"keepalive_time": &schema.Schema{
+				Description:  "Time after which the client pings the server to check the connection, e.g. `30s`. Disabled when not set.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ETCD_KEEPALIVE_TIME", nil),
+				ValidateFunc: validateDuration,
+			},
+			"keepalive_timeout": &schema.Schema{
+				Description:  "Time the client waits for a keepalive answer before closing the connection, e.g. `10s`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ETCD_KEEPALIVE_TIMEOUT", nil),
+				ValidateFunc: validateDuration,
+			},
+			"max_call_send_msg_size": &schema.Schema{
+				Description:  "Maximum size in bytes of a request sent to etcd. The client default is used when set to 0.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_call_recv_msg_size": &schema.Schema{
+				Description:  "Maximum size in bytes of a response received from etcd. The client default is used when set to 0.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"reject_old_cluster": &schema.Schema{
+				Description: "Refuse to connect to a cluster running an outdated etcd version.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"etcd_key":        resourceKey(),
-			"etcd_role":       resourceRole(),
-			"etcd_user":       resourceUser(),
-			"etcd_permission": resourcePermission(),
+			"etcd_key":          resourceKey(),
+			"etcd_role":         resourceRole(),
+			"etcd_user":         resourceUser(),
+			"etcd_permission":   resourcePermission(),
+			"etcd_member":       resourceMember(),
+			"etcd_alarm_disarm": resourceAlarmDisarm(),
+			"etcd_snapshot":     resourceSnapshot(),
+			"etcd_compaction":   resourceCompaction(),
+			"etcd_defragment":   resourceDefragment(),
+			"etcd_leader":       resourceLeader(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"etcd_key":       dataSourceKey(),
-			"etcd_keyprefix": dataSourceKeyPrefix(),
+			"etcd_key":             dataSourceKey(),
+			"etcd_keyprefix":       dataSourceKeyPrefix(),
+			"etcd_members":         dataSourceMembers(),
+			"etcd_endpoint_status": dataSourceEndpointStatus(),
+			"etcd_alarms":          dataSourceAlarms(),
+			"etcd_snapshot_status": dataSourceSnapshotStatus(),
+			"etcd_hashkv":          dataSourceHashKV(),
+			"etcd_prefix_export":   dataSourcePrefixExport(),
+			"etcd_key_history":     dataSourceKeyHistory(),
+			"etcd_prefix_changes":  dataSourcePrefixChanges(),
+			"etcd_key_wait":        dataSourceKeyWait(),
+			"etcd_prefix_stats":    dataSourcePrefixStats(),
+			"etcd_leases":          dataSourceLeases(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	username := d.Get("username").(string)
-	password := d.Get("password").(string)
-	tls := d.Get("tls").(bool)
-	endpoints := strings.Split(d.Get("endpoints").(string), ",")
-
-	// Warning or errors can be collected in a slice type
-	var diags diag.Diagnostics
-	if (username != "") && (password != "") && func(endpointlist []string) bool {
-		for _, e := range endpointlist {
-			if e == "" {
-				return false
-			}
-		}
-		return true
-	}(endpoints) == true {
-		if tls {
-			tlsInfo := transport.TLSInfo{
-				TrustedCAFile: d.Get("ca_cert").(string),
-			}
-			tlsConfig, err := tlsInfo.ClientConfig()
-			if err != nil {
-				return nil, diag.FromErr(err)
-			}
-			c, err := clientv3.New(clientv3.Config{
-				Endpoints:   endpoints,
-				DialTimeout: 5 * time.Second,
-				Username:    username,
-				Password:    password,
-				TLS:         tlsConfig,
-			})
-			if err != nil {
-				return nil, diag.FromErr(err)
-			}
-			return c, diags
-		} else {
-			c, err := clientv3.New(clientv3.Config{
-				Endpoints:   endpoints,
-				DialTimeout: 5 * time.Second,
-				Username:    username,
-				Password:    password,
-			})
-			if err != nil {
-				return nil, diag.FromErr(err)
-			}
-			return c, diags
-		}
-
+	cfg, diags := resolveClientConfig(ctx, d, newSRVResolver(d.Get("discovery_dns_server").(string)))
+	if diags.HasError() {
+		return nil, diags
 	}
 
-	c, err := clientv3.New(clientv3.Config{
-		Endpoints:   []string{"localhost:2379"},
-		DialTimeout: 5 * time.Second,
-	})
+	c, err := clientv3.New(cfg)
 	if err != nil {
-		return nil, diag.FromErr(err)
+		return nil, append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to create etcd client",
+			Detail:   fmt.Sprintf("Failed connecting to %v: %v", strings.Join(cfg.Endpoints, ","), err),
+		})
+	}
+	if healthDiags := checkClusterHealth(ctx, c, cfg.DialTimeout); healthDiags.HasError() {
+		c.Close()
+		return nil, append(diags, healthDiags...)
 	}
 
-	return c, diags
+	return newProviderMeta(c, cfg, d.Get("namespace").(string)), diags
 }
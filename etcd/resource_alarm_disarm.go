@@ -0,0 +1,160 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func resourceAlarmDisarm() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAlarmDisarmCreate,
+		ReadContext:   resourceAlarmDisarmRead,
+		DeleteContext: resourceAlarmDisarmDelete,
+		Schema: map[string]*schema.Schema{
+			"alarm": &schema.Schema{
+				Description: "Alarm type to disarm, NOSPACE or CORRUPT. Every type is disarmed when not set.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(string)
+					if !contains([]string{"NOSPACE", "CORRUPT"}, v) {
+						errs = append(errs, fmt.Errorf("%q must be NOSPACE or CORRUPT, got: %v", key, v))
+					}
+					return
+				},
+			},
+			"member_id": &schema.Schema{
+				Description: "Member whose alarms are disarmed, in the hexadecimal format used by etcdctl. Every member is considered when not set.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"triggers": &schema.Schema{
+				Description: "Arbitrary values that disarm the alarms again when they change.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"disarmed": &schema.Schema{
+				Description: "Alarms disarmed on apply.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        alarmMemberSchema(),
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func resourceAlarmDisarmCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+	var memberID uint64
+	var disarmed []*etcdserverpb.AlarmMember
+
+	cli := m.(*providerMeta).client
+
+	alarm := d.Get("alarm").(string)
+	if v := d.Get("member_id").(string); v != "" {
+		id, err := parseMemberID(v)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		memberID = id
+	}
+
+	resp, err := cli.AlarmList(ctx)
+	if err != nil {
+		return append(diag.FromErr(err), diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "resourceAlarmDisarmCreate error.",
+			Detail:   "Failed calling cli.AlarmList().",
+		})
+	}
+	for _, a := range resp.Alarms {
+		if alarm != "" && a.Alarm.String() != alarm {
+			continue
+		}
+		if memberID != 0 && a.MemberID != memberID {
+			continue
+		}
+		if _, err := cli.AlarmDisarm(ctx, (*clientv3.AlarmMember)(a)); err != nil {
+			return append(diag.FromErr(err), diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "resourceAlarmDisarmCreate error.",
+				Detail:   fmt.Sprintf("Failed disarming alarm %v on member %v.", a.Alarm, formatMemberID(a.MemberID)),
+			})
+		}
+		disarmed = append(disarmed, a)
+	}
+
+	if err := d.Set("disarmed", flattenAlarms(disarmed)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// always run
+	d.SetId(uuidGenerator())
+
+	return diags
+}
+
+func resourceAlarmDisarmRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	// Disarming is a one-shot action, there is nothing to refresh.
+
+	return diags
+}
+
+func resourceAlarmDisarmDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	// Disarmed alarms cannot be raised again, removing the resource only
+	// drops it from the state.
+
+	return diags
+}
+
+func alarmMemberSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"member_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"alarm": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func flattenAlarms(alarms []*etcdserverpb.AlarmMember) []interface{} {
+	result := make([]interface{}, len(alarms), len(alarms))
+
+	for i, a := range alarms {
+		entry := make(map[string]interface{})
+
+		entry["member_id"] = formatMemberID(a.MemberID)
+		entry["alarm"] = a.Alarm.String()
+
+		result[i] = entry
+	}
+
+	return result
+}
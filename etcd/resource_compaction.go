@@ -0,0 +1,142 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func resourceCompaction() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCompactionCreate,
+		ReadContext:   resourceCompactionRead,
+		DeleteContext: resourceCompactionDelete,
+		Schema: map[string]*schema.Schema{
+			"retain_revisions": &schema.Schema{
+				Description:  "Number of revisions kept, compacting up to the current revision minus this value.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				ExactlyOneOf: []string{"retain_revisions", "revision"},
+			},
+			"revision": &schema.Schema{
+				Description:  "Fixed revision to compact up to.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				ExactlyOneOf: []string{"retain_revisions", "revision"},
+			},
+			"physical": &schema.Schema{
+				Description: "Wait until the compacted entries are removed from the backend database.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+			},
+			"triggers": &schema.Schema{
+				Description: "Arbitrary values that run the compaction again when they change.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"compacted_revision": &schema.Schema{
+				Description: "Revision the keyspace has been compacted to. 0 when there was nothing to compact.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func resourceCompactionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+	var revision int64
+	var opts []clientv3.CompactOption
+
+	cli := m.(*providerMeta).client
+
+	if v, ok := d.GetOk("revision"); ok {
+		revision = int64(v.(int))
+	} else {
+		current, err := currentRevision(ctx, cli)
+		if err != nil {
+			return append(diag.FromErr(err), diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "resourceCompactionCreate error.",
+				Detail:   "Failed reading the current revision.",
+			})
+		}
+		revision = current - int64(d.Get("retain_revisions").(int))
+	}
+	compactCtx := ctx
+	if d.Get("physical").(bool) {
+		opts = append(opts, clientv3.WithCompactPhysical())
+		// waiting for the backend can outlast request_timeout
+		compactCtx = withLongRequest(ctx)
+	}
+
+	d.Set("compacted_revision", 0)
+	// always run
+	d.SetId(uuidGenerator())
+
+	if revision <= 0 {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Nothing to compact",
+			Detail:   "The cluster history is shorter than 'retain_revisions'.",
+		})
+	}
+	_, err := cli.Compact(compactCtx, revision, opts...)
+	if err == rpctypes.ErrCompacted {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Nothing to compact",
+			Detail:   fmt.Sprintf("Revision %d has already been compacted.", revision),
+		})
+	}
+	if err != nil {
+		d.SetId("")
+		return append(diag.FromErr(err), diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "resourceCompactionCreate error.",
+			Detail:   fmt.Sprintf("Failed compacting up to revision %d.", revision),
+		})
+	}
+	d.Set("compacted_revision", int(revision))
+
+	return diags
+}
+
+func resourceCompactionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	// Compaction is a one-shot action, there is nothing to refresh.
+
+	return diags
+}
+
+func resourceCompactionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	// A compaction cannot be undone, removing the resource only drops it from
+	// the state.
+
+	return diags
+}
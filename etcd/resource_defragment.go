@@ -0,0 +1,192 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func resourceDefragment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDefragmentCreate,
+		ReadContext:   resourceDefragmentRead,
+		DeleteContext: resourceDefragmentDelete,
+		Schema: map[string]*schema.Schema{
+			"triggers": &schema.Schema{
+				Description: "Arbitrary values that defragment the cluster again when they change.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"members": &schema.Schema{
+				Description: "Members defragmented on apply, in the order they were processed.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"member_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"endpoint": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"db_size_before": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"db_size_after": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+			Create:  schema.DefaultTimeout(30 * time.Minute),
+		},
+	}
+}
+
+func resourceDefragmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	cli := m.(*providerMeta).client
+
+	resp, err := cli.MemberList(ctx)
+	if err != nil {
+		return append(diag.FromErr(err), diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "resourceDefragmentCreate error.",
+			Detail:   "Failed calling cli.MemberList().",
+		})
+	}
+	leader, err := clusterLeaderID(ctx, cli)
+	if err != nil {
+		return append(diag.FromErr(err), diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "resourceDefragmentCreate error.",
+			Detail:   "Failed finding the cluster leader.",
+		})
+	}
+
+	// The leader is defragmented last, so the cluster keeps a responsive
+	// leader for as long as possible.
+	var ordered []*etcdserverpb.Member
+	var leaderMember *etcdserverpb.Member
+	for _, member := range resp.Members {
+		if member.ID == leader {
+			leaderMember = member
+			continue
+		}
+		ordered = append(ordered, member)
+	}
+	if leaderMember != nil {
+		ordered = append(ordered, leaderMember)
+	}
+
+	var members []interface{}
+	for _, member := range ordered {
+		if len(member.ClientURLs) == 0 {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Member skipped",
+				Detail:   fmt.Sprintf("Member %v has no client url, it has probably not been started.", formatMemberID(member.ID)),
+			})
+			continue
+		}
+		entry, err := defragmentMember(ctx, cli, member, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return append(diags, append(diag.FromErr(err), diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "resourceDefragmentCreate error.",
+				Detail:   fmt.Sprintf("Failed defragmenting member %v. The remaining members have been left untouched.", formatMemberID(member.ID)),
+			})...)
+		}
+		members = append(members, entry)
+	}
+
+	if err := d.Set("members", members); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// always run
+	d.SetId(uuidGenerator())
+
+	return diags
+}
+
+// defragmentMember defragments a single member through its first client url
+// and waits until it reports healthy again.
+func defragmentMember(ctx context.Context, cli *clientv3.Client, member *etcdserverpb.Member, timeout time.Duration) (map[string]interface{}, error) {
+	endpoint := member.ClientURLs[0]
+
+	before, err := cli.Status(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := cli.Defragment(withLongRequest(ctx), endpoint); err != nil {
+		return nil, err
+	}
+
+	var after *clientv3.StatusResponse
+	err = resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		var err error
+		after, err = cli.Status(ctx, endpoint)
+		if err != nil {
+			return resource.RetryableError(err)
+		}
+		if len(after.Errors) != 0 {
+			return resource.RetryableError(fmt.Errorf("member %v reports errors: %v", formatMemberID(member.ID), after.Errors))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("member %v did not become healthy after defragmentation: %v", formatMemberID(member.ID), err)
+	}
+
+	entry := make(map[string]interface{})
+
+	entry["member_id"] = formatMemberID(member.ID)
+	entry["name"] = member.Name
+	entry["endpoint"] = endpoint
+	entry["db_size_before"] = int(before.DbSize)
+	entry["db_size_after"] = int(after.DbSize)
+
+	return entry, nil
+}
+
+func resourceDefragmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	// Defragmentation is a one-shot action, there is nothing to refresh.
+
+	return diags
+}
+
+func resourceDefragmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	// Removing the resource only drops it from the state.
+
+	return diags
+}
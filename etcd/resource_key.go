@@ -7,7 +7,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/client/v3/concurrency"
 )
 
@@ -29,6 +28,9 @@ func resourceKey() *schema.Resource {
 				Optional:    true,
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -38,15 +40,12 @@ func resourceKey() *schema.Resource {
 func resourceKeyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	var diags diag.Diagnostics
-	var requestTimeout = 5 * time.Second
 
-	cli := m.(*clientv3.Client)
+	cli := m.(*providerMeta).client
 
-	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
 	key := fmt.Sprintf("%v", d.Get("key"))
 	value := fmt.Sprintf("%v", d.Get("value"))
 	resp, err := cli.Get(ctx, key)
-	cancel()
 	if err != nil {
 		return append(diag.FromErr(err), diag.Diagnostic{
 			Severity: diag.Error,
@@ -62,9 +61,7 @@ func resourceKeyCreate(ctx context.Context, d *schema.ResourceData, m interface{
 		})
 	}
 
-	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
 	_, put_err := cli.Put(ctx, key, value)
-	cancel()
 	if put_err != nil {
 		return append(diag.FromErr(err), diag.Diagnostic{
 			Severity: diag.Error,
@@ -83,32 +80,28 @@ func resourceKeyCreate(ctx context.Context, d *schema.ResourceData, m interface{
 func resourceKeyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	var diags diag.Diagnostics
-	var requestTimeout = 5 * time.Second
 
-	cli := m.(*clientv3.Client)
+	cli := m.(*providerMeta).client
 
-	session, err := concurrency.NewSession(cli)
+	session, err := concurrency.NewSession(cli, concurrency.WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer session.Close()
 
-	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
-
 	key := fmt.Sprintf("%v", d.Get("key"))
 	if key == "" {
 		key = string(d.Id())
 		d.Set("key", key)
 	}
 	m1 := concurrency.NewMutex(session, fmt.Sprintf("/resourceKeyRead/%v", key))
-	if err := m1.Lock(context.TODO()); err != nil {
+	if err := m1.Lock(ctx); err != nil {
 		return diag.FromErr(err)
 	}
 	resp, err := cli.Get(ctx, key)
-	if err := m1.Unlock(context.TODO()); err != nil {
+	if err := m1.Unlock(ctx); err != nil {
 		return diag.FromErr(err)
 	}
-	cancel()
 	if err != nil {
 		return append(diag.FromErr(err), diag.Diagnostic{
 			Severity: diag.Error,
@@ -163,36 +156,33 @@ func resourceKeyRead(ctx context.Context, d *schema.ResourceData, m interface{})
 func resourceKeyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	var diags diag.Diagnostics
-	var requestTimeout = 5 * time.Second
 	if d.HasChange("value") {
 
-		cli := m.(*clientv3.Client)
+		cli := m.(*providerMeta).client
 
-		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
 		key := fmt.Sprintf("%v", d.Get("key"))
 		value := fmt.Sprintf("%v", d.Get("value"))
 
-		session, err := concurrency.NewSession(cli)
+		session, err := concurrency.NewSession(cli, concurrency.WithContext(ctx))
 		if err != nil {
 			return diag.FromErr(err)
 		}
 		defer session.Close()
 
 		m1 := concurrency.NewMutex(session, fmt.Sprintf("/resourceKeyRead/%v", key))
-		if err := m1.Lock(context.TODO()); err != nil {
+		if err := m1.Lock(ctx); err != nil {
 			return diag.FromErr(err)
 		}
 
-		if err := m1.Lock(context.TODO()); err != nil {
+		if err := m1.Lock(ctx); err != nil {
 			return diag.FromErr(err)
 		}
 		// Should I do a Get()?
 		resp, err := cli.Get(ctx, key)
-		if err := m1.Unlock(context.TODO()); err != nil {
+		if err := m1.Unlock(ctx); err != nil {
 			return diag.FromErr(err)
 		}
 
-		cancel()
 		if err != nil {
 			return append(diag.FromErr(err), diag.Diagnostic{
 				Severity: diag.Error,
@@ -208,15 +198,13 @@ func resourceKeyUpdate(ctx context.Context, d *schema.ResourceData, m interface{
 			})
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
-		if err := m1.Lock(context.TODO()); err != nil {
+		if err := m1.Lock(ctx); err != nil {
 			return diag.FromErr(err)
 		}
 		_, put_err := cli.Put(ctx, key, value)
-		if err := m1.Unlock(context.TODO()); err != nil {
+		if err := m1.Unlock(ctx); err != nil {
 			return diag.FromErr(err)
 		}
-		cancel()
 		if put_err != nil {
 			return append(diag.FromErr(err), diag.Diagnostic{
 				Severity: diag.Error,
@@ -237,32 +225,29 @@ func resourceKeyUpdate(ctx context.Context, d *schema.ResourceData, m interface{
 func resourceKeyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	var diags diag.Diagnostics
-	var requestTimeout = 5 * time.Second
-	cli := m.(*clientv3.Client)
+	cli := m.(*providerMeta).client
 
-	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
 	key := fmt.Sprintf("%v", d.Get("key"))
 
-	session, err := concurrency.NewSession(cli)
+	session, err := concurrency.NewSession(cli, concurrency.WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer session.Close()
 
 	m1 := concurrency.NewMutex(session, fmt.Sprintf("/resourceKeyRead/%v", key))
-	if err := m1.Lock(context.TODO()); err != nil {
+	if err := m1.Lock(ctx); err != nil {
 		return diag.FromErr(err)
 	}
 
-	if err := m1.Lock(context.TODO()); err != nil {
+	if err := m1.Lock(ctx); err != nil {
 		return diag.FromErr(err)
 	}
 	// Should I do a Get()?
 	_, derr := cli.Delete(ctx, key)
-	if err := m1.Unlock(context.TODO()); err != nil {
+	if err := m1.Unlock(ctx); err != nil {
 		return diag.FromErr(err)
 	}
-	cancel()
 	if derr != nil {
 		return append(diag.FromErr(err), diag.Diagnostic{
 			Severity: diag.Error,
@@ -0,0 +1,197 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+func resourceLeader() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceLeaderCreate,
+		ReadContext:   resourceLeaderRead,
+		UpdateContext: resourceLeaderUpdate,
+		DeleteContext: resourceLeaderDelete,
+		Schema: map[string]*schema.Schema{
+			"member_id": &schema.Schema{
+				Description:  "Member that must lead the cluster, in the hexadecimal format used by etcdctl.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"member_id", "member_name"},
+			},
+			"member_name": &schema.Schema{
+				Description:  "Name of the member that must lead the cluster.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"member_id", "member_name"},
+			},
+			"leader_id": &schema.Schema{
+				Description: "Current leader ID.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"leader_name": &schema.Schema{
+				Description: "Current leader name.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func resourceLeaderCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	if diags := moveLeader(ctx, d, m); diags.HasError() {
+		return diags
+	}
+	// always run
+	d.SetId(uuidGenerator())
+
+	return resourceLeaderRead(ctx, d, m)
+}
+
+func resourceLeaderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	cli := m.(*providerMeta).client
+
+	resp, err := cli.MemberList(ctx)
+	if err != nil {
+		return append(diag.FromErr(err), diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "resourceLeaderRead error.",
+			Detail:   "Failed calling cli.MemberList() from resourceLeaderRead().",
+		})
+	}
+	leaderID, err := clusterLeaderID(ctx, cli)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	leader := findMember(resp.Members, leaderID)
+	if leader == nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "resourceLeaderRead error.",
+			Detail:   fmt.Sprintf("The leader %v is not part of the member list.", formatMemberID(leaderID)),
+		})
+	}
+
+	d.Set("leader_id", formatMemberID(leader.ID))
+	d.Set("leader_name", leader.Name)
+	// Report the actual leader on the configured target so a leadership
+	// change made outside of terraform shows up as drift.
+	if d.Get("member_name").(string) != "" {
+		d.Set("member_name", leader.Name)
+	} else {
+		d.Set("member_id", formatMemberID(leader.ID))
+	}
+
+	return diags
+}
+
+func resourceLeaderUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	if diags := moveLeader(ctx, d, m); diags.HasError() {
+		return diags
+	}
+
+	return resourceLeaderRead(ctx, d, m)
+}
+
+func resourceLeaderDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	// The cluster always has a leader, removing the resource only drops it
+	// from the state.
+
+	return diags
+}
+
+// moveLeader transfers the leadership to the configured member. The request is
+// sent to the current leader, as required by etcd.
+func moveLeader(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+	var target *etcdserverpb.Member
+
+	meta := m.(*providerMeta)
+	cli := meta.client
+
+	resp, err := cli.MemberList(ctx)
+	if err != nil {
+		return append(diag.FromErr(err), diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "moveLeader error.",
+			Detail:   "Failed calling cli.MemberList().",
+		})
+	}
+	if name := d.Get("member_name").(string); name != "" {
+		for _, member := range resp.Members {
+			if member.Name == name {
+				target = member
+				break
+			}
+		}
+	} else {
+		id, err := parseMemberID(d.Get("member_id").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		target = findMember(resp.Members, id)
+	}
+	if target == nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "moveLeader error.",
+			Detail:   "The target member is not part of the cluster.",
+		})
+	}
+	if target.IsLearner {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "moveLeader error.",
+			Detail:   fmt.Sprintf("Member %v is a learner and cannot lead the cluster.", formatMemberID(target.ID)),
+		})
+	}
+
+	leaderID, err := clusterLeaderID(ctx, cli)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if leaderID == target.ID {
+		return diags
+	}
+	leader := findMember(resp.Members, leaderID)
+	if leader == nil || len(leader.ClientURLs) == 0 {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "moveLeader error.",
+			Detail:   fmt.Sprintf("Cannot find a client url for the current leader %v.", formatMemberID(leaderID)),
+		})
+	}
+
+	leaderCli, err := meta.endpointClient(leader.ClientURLs[0])
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer leaderCli.Close()
+
+	if _, err := leaderCli.MoveLeader(ctx, target.ID); err != nil {
+		return append(diag.FromErr(err), diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "moveLeader error.",
+			Detail:   fmt.Sprintf("Failed moving the leadership from %v to %v.", formatMemberID(leaderID), formatMemberID(target.ID)),
+		})
+	}
+
+	return diags
+}
@@ -0,0 +1,227 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func resourceMember() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMemberCreate,
+		ReadContext:   resourceMemberRead,
+		UpdateContext: resourceMemberUpdate,
+		DeleteContext: resourceMemberDelete,
+		Schema: map[string]*schema.Schema{
+			"peer_urls": &schema.Schema{
+				Description: "URLs the member uses to talk to its peers.",
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"is_learner": &schema.Schema{
+				Description: "Add the member as a non-voting learner. Switching it to `false` promotes the learner once it is in sync with the leader, within the `update` timeout.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"member_id": &schema.Schema{
+				Description: "Member ID, in the hexadecimal format used by etcdctl.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"name": &schema.Schema{
+				Description: "Member name. Empty until the member is started.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"client_urls": &schema.Schema{
+				Description: "URLs the member serves clients on. Empty until the member is started.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		CustomizeDiff: resourceMemberCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+			Update:  schema.DefaultTimeout(10 * time.Minute),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+// resourceMemberCustomizeDiff refuses to turn a voting member back into a
+// learner, which etcd does not support. Replacing the member instead could
+// remove a live voter, so it is left to an explicit -replace.
+func resourceMemberCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	old_value, new_value := d.GetChange("is_learner")
+	if !old_value.(bool) && new_value.(bool) {
+		return fmt.Errorf("member %v is a voting member and cannot be demoted; set is_learner = false", d.Get("member_id"))
+	}
+	return nil
+}
+
+func resourceMemberCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var resp *clientv3.MemberAddResponse
+	var err error
+
+	cli := m.(*providerMeta).client
+
+	peerURLs := expandStringList(d.Get("peer_urls").([]interface{}))
+	if d.Get("is_learner").(bool) {
+		resp, err = cli.MemberAddAsLearner(ctx, peerURLs)
+	} else {
+		resp, err = cli.MemberAdd(ctx, peerURLs)
+	}
+	if err != nil {
+		return append(diag.FromErr(err), diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "resourceMemberCreate error.",
+			Detail:   fmt.Sprintf("Failed adding member with peer urls: %v", peerURLs),
+		})
+	}
+
+	d.Set("member_id", formatMemberID(resp.Member.ID))
+	// always run
+	d.SetId(uuidGenerator())
+
+	return resourceMemberRead(ctx, d, m)
+}
+
+func resourceMemberRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	cli := m.(*providerMeta).client
+
+	memberID := d.Get("member_id").(string)
+	if memberID == "" {
+		memberID = d.Id()
+	}
+	id, err := parseMemberID(memberID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resp, err := cli.MemberList(ctx)
+	if err != nil {
+		return append(diag.FromErr(err), diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "resourceMemberRead error.",
+			Detail:   "Failed calling cli.MemberList() from resourceMemberRead().",
+		})
+	}
+	member := findMember(resp.Members, id)
+	if member == nil {
+		// The member has been removed outside of terraform.
+		d.SetId("")
+		return diags
+	}
+
+	d.Set("member_id", formatMemberID(member.ID))
+	d.Set("name", member.Name)
+	d.Set("peer_urls", member.PeerURLs)
+	d.Set("client_urls", member.ClientURLs)
+	d.Set("is_learner", member.IsLearner)
+	if d.Id() == memberID {
+		// imported using the member id
+		d.SetId(uuidGenerator())
+	}
+
+	return diags
+}
+
+func resourceMemberUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	cli := m.(*providerMeta).client
+
+	id, err := parseMemberID(d.Get("member_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if d.HasChange("peer_urls") {
+		peerURLs := expandStringList(d.Get("peer_urls").([]interface{}))
+		if _, err := cli.MemberUpdate(ctx, id, peerURLs); err != nil {
+			return append(diag.FromErr(err), diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "resourceMemberUpdate error.",
+				Detail:   fmt.Sprintf("Failed updating peer urls of member %v to: %v", formatMemberID(id), peerURLs),
+			})
+		}
+	}
+	if d.HasChange("is_learner") && !d.Get("is_learner").(bool) {
+		if diags := promoteMember(ctx, cli, id, d.Timeout(schema.TimeoutUpdate)); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceMemberRead(ctx, d, m)
+}
+
+// promoteMember turns a learner into a voting member, polling until it has
+// caught up with the leader or timeout elapses.
+func promoteMember(ctx context.Context, cli *clientv3.Client, id uint64, timeout time.Duration) diag.Diagnostics {
+	err := resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		_, err := cli.MemberPromote(ctx, id)
+		if err == rpctypes.ErrMemberLearnerNotReady {
+			return resource.RetryableError(err)
+		}
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return append(diag.FromErr(err), diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "resourceMemberUpdate error.",
+			Detail:   fmt.Sprintf("Failed promoting learner member %v.", formatMemberID(id)),
+		})
+	}
+	return nil
+}
+
+func resourceMemberDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	cli := m.(*providerMeta).client
+
+	id, err := parseMemberID(d.Get("member_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := checkMemberRemoval(ctx, cli, id); err != nil {
+		return append(diag.FromErr(err), diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "resourceMemberDelete error.",
+			Detail:   fmt.Sprintf("Refusing to remove member %v.", formatMemberID(id)),
+		})
+	}
+	if _, err := cli.MemberRemove(ctx, id); err != nil && err != rpctypes.ErrMemberNotFound {
+		return append(diag.FromErr(err), diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "resourceMemberDelete error.",
+			Detail:   fmt.Sprintf("Failed removing member %v.", formatMemberID(id)),
+		})
+	}
+
+	return diags
+}
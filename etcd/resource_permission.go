@@ -46,6 +46,9 @@ func resourcePermission() *schema.Resource {
 				},
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -55,19 +58,16 @@ func resourcePermission() *schema.Resource {
 func resourcePermissionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	var diags diag.Diagnostics
-	var requestTimeout = 5 * time.Second
 	var rangeEnd string
 	var permission clientv3.PermissionType
 
-	cli := m.(*clientv3.Client)
-	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	meta := m.(*providerMeta)
+	cli := meta.client
 
 	role := fmt.Sprintf("%v", d.Get("role"))
 	key := fmt.Sprintf("%v", d.Get("key"))
 	withPrefix := d.Get("withprefix").(bool)
-	if withPrefix == true {
-		rangeEnd = clientv3.GetPrefixRangeEnd(key)
-	} else {
+	if withPrefix == false {
 		rangeEnd = d.Get("endrange").(string)
 		if rangeEnd == "" {
 			return append(diags, diag.Diagnostic{
@@ -82,8 +82,8 @@ func resourcePermissionCreate(ctx context.Context, d *schema.ResourceData, m int
 	} else {
 		permission = clientv3.PermissionType(clientv3.PermRead)
 	}
-	_, err := cli.RoleGrantPermission(ctx, role, key, rangeEnd, permission)
-	cancel()
+	permKey, permRangeEnd := meta.permissionRange(key, rangeEnd, withPrefix)
+	_, err := cli.RoleGrantPermission(ctx, role, permKey, permRangeEnd, permission)
 	if err != nil {
 		return append(diag.FromErr(err), diag.Diagnostic{
 			Severity: diag.Error,
@@ -103,15 +103,13 @@ func resourcePermissionCreate(ctx context.Context, d *schema.ResourceData, m int
 func resourcePermissionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	var diags diag.Diagnostics
-	var requestTimeout = 5 * time.Second
 
-	cli := m.(*clientv3.Client)
-	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	meta := m.(*providerMeta)
+	cli := meta.client
 
 	role := fmt.Sprintf("%v", d.Get("role"))
 	key := fmt.Sprintf("%v", d.Get("key"))
 	resp, err := cli.RoleGet(ctx, role)
-	cancel()
 	if err != nil {
 		return append(diag.FromErr(err), diag.Diagnostic{
 			Severity: diag.Error,
@@ -119,14 +117,15 @@ func resourcePermissionRead(ctx context.Context, d *schema.ResourceData, m inter
 			Detail:   fmt.Sprintf("Failed getting role: %v", role),
 		})
 	}
+	permKey, prefixRangeEnd := meta.permissionRange(key, "", true)
 	for _, p := range resp.Perm {
-		if string(p.Key) != key {
+		if string(p.Key) != permKey {
 			continue
 		}
-		if string(p.RangeEnd) == clientv3.GetPrefixRangeEnd(key) {
-			d.Set("withPrefix", true)
+		if string(p.RangeEnd) == prefixRangeEnd {
+			d.Set("withprefix", true)
 		} else {
-			d.Set("withPrefix", false)
+			d.Set("withprefix", false)
 		}
 		d.Set("permission", fmt.Sprintf("%v", p.PermType))
 		d.SetId(uuidGenerator())
@@ -138,19 +137,16 @@ func resourcePermissionRead(ctx context.Context, d *schema.ResourceData, m inter
 func resourcePermissionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	var diags diag.Diagnostics
-	var requestTimeout = 5 * time.Second
 	var rangeEnd string
 	var permission clientv3.PermissionType
 
-	cli := m.(*clientv3.Client)
-	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	meta := m.(*providerMeta)
+	cli := meta.client
 
 	role := fmt.Sprintf("%v", d.Get("role"))
 	key := fmt.Sprintf("%v", d.Get("key"))
 	withPrefix := d.Get("withprefix").(bool)
-	if withPrefix == true {
-		rangeEnd = clientv3.GetPrefixRangeEnd(key)
-	} else {
+	if withPrefix == false {
 		rangeEnd = d.Get("endrange").(string)
 		if rangeEnd == "" {
 			return append(diags, diag.Diagnostic{
@@ -165,8 +161,8 @@ func resourcePermissionUpdate(ctx context.Context, d *schema.ResourceData, m int
 	} else {
 		permission = clientv3.PermissionType(clientv3.PermRead)
 	}
-	_, err := cli.RoleGrantPermission(ctx, role, key, rangeEnd, permission)
-	cancel()
+	permKey, permRangeEnd := meta.permissionRange(key, rangeEnd, withPrefix)
+	_, err := cli.RoleGrantPermission(ctx, role, permKey, permRangeEnd, permission)
 	if err != nil {
 		return append(diag.FromErr(err), diag.Diagnostic{
 			Severity: diag.Error,
@@ -185,17 +181,15 @@ func resourcePermissionUpdate(ctx context.Context, d *schema.ResourceData, m int
 func resourcePermissionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	var diags diag.Diagnostics
-	var requestTimeout = 5 * time.Second
 
-	cli := m.(*clientv3.Client)
-	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	meta := m.(*providerMeta)
+	cli := meta.client
 
 	role := fmt.Sprintf("%v", d.Get("role"))
 	key := fmt.Sprintf("%v", d.Get("key"))
-	rangeEnd := d.Get("endrange").(string)
+	permKey, permRangeEnd := meta.permissionRange(key, d.Get("endrange").(string), d.Get("withprefix").(bool))
 
 	resp, err := cli.RoleGet(ctx, role)
-	cancel()
 	if err != nil {
 		return append(diag.FromErr(err), diag.Diagnostic{
 			Severity: diag.Error,
@@ -204,12 +198,10 @@ func resourcePermissionDelete(ctx context.Context, d *schema.ResourceData, m int
 		})
 	}
 	for _, p := range resp.Perm {
-		if string(p.Key) != key || string(p.RangeEnd) != rangeEnd {
+		if string(p.Key) != permKey || string(p.RangeEnd) != permRangeEnd {
 			continue
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
-		_, err = cli.RoleRevokePermission(ctx, role, key, rangeEnd)
-		cancel()
+		_, err = cli.RoleRevokePermission(ctx, role, permKey, permRangeEnd)
 		if err != nil {
 			return append(diag.FromErr(err), diag.Diagnostic{
 				Severity: diag.Error,
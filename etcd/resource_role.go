@@ -23,6 +23,9 @@ func resourceRole() *schema.Resource {
 				Optional: true,
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -32,17 +35,14 @@ func resourceRole() *schema.Resource {
 func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	var diags diag.Diagnostics
-	var requestTimeout = 5 * time.Second
 
-	cli := m.(*clientv3.Client)
+	cli := m.(*providerMeta).client
 
 	name := fmt.Sprintf("%v", d.Get("name"))
 	//key := fmt.Sprintf("%v", d.Get("key"))
 	//withPrefix := fmt.Sprintf("%v", d.Get("withPrefix"))
 	//permission := fmt.Sprintf("%v", d.Get("permission"))
-	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
 	_, err := cli.RoleGet(ctx, name)
-	cancel()
 	if err == nil {
 		return append(diag.FromErr(err), diag.Diagnostic{
 			Severity: diag.Error,
@@ -50,9 +50,7 @@ func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, m interface
 			Detail:   fmt.Sprintf("The role %v already exist and it is not managed by this terraform.", name),
 		})
 	}
-	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
 	_, err = cli.RoleAdd(ctx, name)
-	cancel()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -67,18 +65,15 @@ func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, m interface
 func resourceRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	var diags diag.Diagnostics
-	var requestTimeout = 5 * time.Second
 
-	cli := m.(*clientv3.Client)
+	cli := m.(*providerMeta).client
 
 	name := fmt.Sprintf("%v", d.Get("name"))
 	if name == "" {
 		name = string(d.Id())
 		d.Set("name", name)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
 	_, err := cli.RoleGet(ctx, name)
-	cancel()
 	if err != nil {
 		return append(diag.FromErr(err), diag.Diagnostic{
 			Severity: diag.Error,
@@ -94,15 +89,12 @@ func resourceRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}
 func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	var diags diag.Diagnostics
-	var requestTimeout = 5 * time.Second
 
 	old_value, new_value := d.GetChange("name")
 
-	cli := m.(*clientv3.Client)
+	cli := m.(*providerMeta).client
 
-	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
 	role, err := cli.RoleGet(ctx, fmt.Sprintf("%v", old_value))
-	cancel()
 	if err != nil {
 		return append(diag.FromErr(err), diag.Diagnostic{
 			Severity: diag.Error,
@@ -110,9 +102,7 @@ func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface
 			Detail:   fmt.Sprintf("The original role %v doesn't exist.", old_value),
 		})
 	}
-	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
 	_, err = cli.RoleGet(ctx, fmt.Sprintf("%v", new_value))
-	cancel()
 	if err == nil {
 		return append(diag.FromErr(err), diag.Diagnostic{
 			Severity: diag.Error,
@@ -121,20 +111,16 @@ func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface
 		})
 	}
 	// Creating new role
-	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
 	_, err = cli.RoleAdd(ctx, fmt.Sprintf("%v", new_value))
-	cancel()
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	for _, p := range role.Perm {
-		ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
 		if fmt.Sprintf("%v", p.PermType) == "READWRITE" {
 			_, err = cli.RoleGrantPermission(ctx, fmt.Sprintf("%v", new_value), string(p.Key), string(p.RangeEnd), clientv3.PermissionType(clientv3.PermReadWrite))
 		} else {
 			_, err = cli.RoleGrantPermission(ctx, fmt.Sprintf("%v", new_value), string(p.Key), string(p.RangeEnd), clientv3.PermissionType(clientv3.PermRead))
 		}
-		cancel()
 		if err != nil {
 			return append(diags, diag.Diagnostic{
 				Severity: diag.Error,
@@ -143,9 +129,7 @@ func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface
 			})
 		}
 	}
-	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
 	_, err = cli.RoleDelete(ctx, fmt.Sprintf("%v", old_value))
-	cancel()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -161,17 +145,14 @@ func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface
 func resourceRoleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	var diags diag.Diagnostics
-	var requestTimeout = 5 * time.Second
 
-	cli := m.(*clientv3.Client)
+	cli := m.(*providerMeta).client
 
 	name := fmt.Sprintf("%v", d.Get("name"))
 	//key := fmt.Sprintf("%v", d.Get("key"))
 	//withPrefix := fmt.Sprintf("%v", d.Get("withPrefix"))
 	//permission := fmt.Sprintf("%v", d.Get("permission"))
-	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
 	_, err := cli.RoleGet(ctx, name)
-	cancel()
 	if err != nil {
 		return append(diag.FromErr(err), diag.Diagnostic{
 			Severity: diag.Error,
@@ -179,9 +160,7 @@ func resourceRoleDelete(ctx context.Context, d *schema.ResourceData, m interface
 			Detail:   fmt.Sprintf("The role %v doesn't exist.", name),
 		})
 	}
-	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
 	_, err = cli.RoleDelete(ctx, name)
-	cancel()
 	if err != nil {
 		return diag.FromErr(err)
 	}
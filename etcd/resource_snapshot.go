@@ -0,0 +1,123 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceSnapshot() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSnapshotCreate,
+		ReadContext:   resourceSnapshotRead,
+		DeleteContext: resourceSnapshotDelete,
+		Schema: map[string]*schema.Schema{
+			"path": &schema.Schema{
+				Description: "Local file the snapshot is written to.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"triggers": &schema.Schema{
+				Description: "Arbitrary values that take a new snapshot when they change.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"sha256": &schema.Schema{
+				Description: "sha256 of the snapshot file.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"size": &schema.Schema{
+				Description: "Size of the snapshot file in bytes.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"revision": &schema.Schema{
+				Description: "Revision of the data saved in the snapshot.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"taken_at": &schema.Schema{
+				Description: "RFC3339 time the snapshot was taken.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+			Create:  schema.DefaultTimeout(20 * time.Minute),
+		},
+	}
+}
+
+func resourceSnapshotCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	cli := m.(*providerMeta).client
+
+	path := d.Get("path").(string)
+	takenAt := time.Now()
+	sum, size, err := saveSnapshot(ctx, cli, path)
+	if err != nil {
+		return append(diag.FromErr(err), diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "resourceSnapshotCreate error.",
+			Detail:   fmt.Sprintf("Failed saving snapshot into %v.", path),
+		})
+	}
+
+	status, err := readSnapshotStatus(path)
+	if err != nil {
+		return append(diag.FromErr(err), diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "resourceSnapshotCreate error.",
+			Detail:   fmt.Sprintf("Failed reading the status of snapshot %v.", path),
+		})
+	}
+
+	d.Set("sha256", sum)
+	d.Set("size", int(size))
+	d.Set("revision", int(status.Revision))
+	d.Set("taken_at", takenAt.UTC().Format(time.RFC3339))
+	// always run
+	d.SetId(uuidGenerator())
+
+	return diags
+}
+
+func resourceSnapshotRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	// A snapshot removed or replaced on disk is taken again.
+	sum, err := fileSHA256(d.Get("path").(string))
+	if os.IsNotExist(err) || (err == nil && sum != d.Get("sha256").(string)) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceSnapshotDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	// Backups are kept on disk, removing the resource only drops it from the
+	// state.
+
+	return diags
+}
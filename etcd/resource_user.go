@@ -9,7 +9,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
 var (
@@ -36,6 +35,9 @@ func resourceUser() *schema.Resource {
 				Optional: true,
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -79,9 +81,8 @@ func generatePassword(passwdLen, nSpecialChar, nNum, nUpperCase int) string {
 func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	var diags diag.Diagnostics
-	var requestTimeout = 5 * time.Second
 
-	cli := m.(*clientv3.Client)
+	cli := m.(*providerMeta).client
 
 	name := fmt.Sprintf("%v", d.Get("name"))
 	password := fmt.Sprintf("%v", d.Get("password"))
@@ -89,9 +90,7 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface
 		password = generatePassword(24, 3, 3, 3)
 		d.Set("password", password)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
 	_, err := cli.UserAdd(ctx, name, password)
-	cancel()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -106,18 +105,15 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface
 func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	var diags diag.Diagnostics
-	var requestTimeout = 5 * time.Second
 
-	cli := m.(*clientv3.Client)
+	cli := m.(*providerMeta).client
 
 	name := fmt.Sprintf("%v", d.Get("name"))
 	if name == "" {
 		name = string(d.Id())
 		d.Set("name", name)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
 	_, err := cli.UserGet(ctx, name)
-	cancel()
 	if err != nil {
 		return append(diag.FromErr(err), diag.Diagnostic{
 			Severity: diag.Error,
@@ -133,11 +129,10 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}
 func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	var diags diag.Diagnostics
-	//var requestTimeout = 5 * time.Second
 
 	//old_value, new_value := d.GetChange("name")
 
-	//cli := m.(*clientv3.Client)
+	//cli := m.(*providerMeta).client
 
 	//ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
 	//role, err := cli.RoleGet(ctx, fmt.Sprintf("%v", old_value))
@@ -200,14 +195,11 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface
 func resourceUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	var diags diag.Diagnostics
-	var requestTimeout = 5 * time.Second
 
-	cli := m.(*clientv3.Client)
+	cli := m.(*providerMeta).client
 
 	name := fmt.Sprintf("%v", d.Get("name"))
-	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
 	_, err := cli.UserDelete(ctx, name)
-	cancel()
 	if err != nil {
 		return diag.FromErr(err)
 	}
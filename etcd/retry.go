@@ -0,0 +1,97 @@
+//
+// retry.go
+// Copyright (C) 2021 rmelo <Ricardo Melo <rmelo@ludia.com>>
+//
+// Distributed under terms of the MIT license.
+//
+
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// longRequestKey marks contexts whose requests are not bounded by
+// request_timeout.
+type longRequestKey struct{}
+
+// withLongRequest returns a context for calls that legitimately outlast
+// request_timeout, like Defragment. They stay bounded by the caller context.
+func withLongRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, longRequestKey{}, true)
+}
+
+// readOnlyMethods lists the RPCs that can be sent again without side effects.
+var readOnlyMethods = map[string]bool{
+	"/etcdserverpb.KV/Range":              true,
+	"/etcdserverpb.Cluster/MemberList":    true,
+	"/etcdserverpb.Maintenance/Status":    true,
+	"/etcdserverpb.Maintenance/Hash":      true,
+	"/etcdserverpb.Maintenance/HashKV":    true,
+	"/etcdserverpb.Lease/LeaseTimeToLive": true,
+	"/etcdserverpb.Lease/LeaseLeases":     true,
+	"/etcdserverpb.Auth/UserGet":          true,
+	"/etcdserverpb.Auth/UserList":         true,
+	"/etcdserverpb.Auth/RoleGet":          true,
+	"/etcdserverpb.Auth/RoleList":         true,
+}
+
+// retryUnaryInterceptor bounds every unary call made by the etcd client with
+// requestTimeout, retries included. Read-only calls are retried up to
+// maxRetries times while etcd answers Unavailable (no leader, leader changed,
+// endpoint down); the wait between attempts starts at backoff and doubles
+// each time. Other calls are sent once. The caller context, which carries the
+// Terraform timeouts, always wins.
+//
+// The etcd client wraps this interceptor with its own retry loop, which would
+// start over on timeouts and Unavailable answers. Those errors are therefore
+// returned as plain errors once this interceptor gives up, so the call ends
+// here.
+func retryUnaryInterceptor(requestTimeout time.Duration, maxRetries int, backoff time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		timeout := requestTimeout
+		if ctx.Value(longRequestKey{}) != nil {
+			timeout = 0
+		}
+		var callCtx context.Context
+		var cancel context.CancelFunc
+		if timeout > 0 {
+			callCtx, cancel = context.WithTimeout(ctx, timeout)
+		} else {
+			callCtx, cancel = context.WithCancel(ctx)
+		}
+		defer cancel()
+
+		wait := backoff
+		for attempt := 0; ; attempt++ {
+			err := invoker(callCtx, method, req, reply, cc, opts...)
+			if err == nil {
+				return nil
+			}
+			if ctx.Err() != nil {
+				return err
+			}
+			if callCtx.Err() != nil {
+				return fmt.Errorf("%v: no answer within request_timeout (%v)", method, timeout)
+			}
+			if status.Code(err) != codes.Unavailable {
+				return err
+			}
+			if !readOnlyMethods[method] || attempt >= maxRetries {
+				return fmt.Errorf("%v: etcd unavailable after %d attempts: %v", method, attempt+1, err)
+			}
+			select {
+			case <-callCtx.Done():
+				return fmt.Errorf("%v: etcd unavailable within request_timeout (%v): %v", method, timeout, err)
+			case <-time.After(wait):
+			}
+			wait *= 2
+		}
+	}
+}
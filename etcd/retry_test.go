@@ -0,0 +1,157 @@
+package etcd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryUnaryInterceptor(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "etcdserver: no leader")
+	notFound := status.Error(codes.NotFound, "etcdserver: key not found")
+
+	cases := []struct {
+		name           string
+		method         string
+		requestTimeout time.Duration
+		maxRetries     int
+		long           bool
+		cancel         bool
+		// answers are returned by the successive attempts, the last one is
+		// repeated
+		answers []error
+		// delay is spent by every attempt, or until its context is done
+		delay time.Duration
+		// attempts is not checked when 0, within is not checked when 0
+		attempts int
+		within   time.Duration
+		err      error
+		wantErr  bool
+	}{
+		{
+			name:     "success",
+			method:   "/etcdserverpb.KV/Range",
+			answers:  []error{nil},
+			attempts: 1,
+		},
+		{
+			name:       "read retried until it succeeds",
+			method:     "/etcdserverpb.KV/Range",
+			maxRetries: 3,
+			answers:    []error{unavailable, unavailable, nil},
+			attempts:   3,
+		},
+		{
+			name:       "read retried up to max_retries",
+			method:     "/etcdserverpb.KV/Range",
+			maxRetries: 2,
+			answers:    []error{unavailable},
+			attempts:   3,
+			wantErr:    true,
+		},
+		{
+			name:       "write sent once",
+			method:     "/etcdserverpb.KV/Put",
+			maxRetries: 3,
+			answers:    []error{unavailable},
+			attempts:   1,
+			wantErr:    true,
+		},
+		{
+			name:       "other errors not retried",
+			method:     "/etcdserverpb.KV/Range",
+			maxRetries: 3,
+			answers:    []error{notFound},
+			attempts:   1,
+			err:        notFound,
+		},
+		{
+			name:           "request_timeout bounds every attempt together",
+			method:         "/etcdserverpb.KV/Range",
+			requestTimeout: 50 * time.Millisecond,
+			maxRetries:     10,
+			answers:        []error{unavailable},
+			delay:          20 * time.Millisecond,
+			within:         500 * time.Millisecond,
+			wantErr:        true,
+		},
+		{
+			name:           "long request not bounded by request_timeout",
+			method:         "/etcdserverpb.Maintenance/Defragment",
+			requestTimeout: 10 * time.Millisecond,
+			long:           true,
+			answers:        []error{nil},
+			delay:          50 * time.Millisecond,
+			attempts:       1,
+		},
+		{
+			name:       "caller cancellation returns the original error",
+			method:     "/etcdserverpb.KV/Range",
+			maxRetries: 3,
+			cancel:     true,
+			answers:    []error{unavailable},
+			attempts:   1,
+			err:        unavailable,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if c.long {
+				ctx = withLongRequest(ctx)
+			}
+
+			attempts := 0
+			invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				answer := c.answers[len(c.answers)-1]
+				if attempts < len(c.answers) {
+					answer = c.answers[attempts]
+				}
+				attempts++
+				if c.cancel {
+					// the caller gives up while etcd answers
+					cancel()
+					return answer
+				}
+				select {
+				case <-ctx.Done():
+					return status.FromContextError(ctx.Err()).Err()
+				case <-time.After(c.delay):
+				}
+				return answer
+			}
+
+			interceptor := retryUnaryInterceptor(c.requestTimeout, c.maxRetries, time.Millisecond)
+			start := time.Now()
+			err := interceptor(ctx, c.method, nil, nil, nil, invoker)
+
+			if c.within != 0 && time.Since(start) > c.within {
+				t.Errorf("expected the call to end within %v, took %v", c.within, time.Since(start))
+			}
+			if c.attempts != 0 && attempts != c.attempts {
+				t.Errorf("expected %d attempts, got %d", c.attempts, attempts)
+			}
+			switch {
+			case c.err != nil:
+				if !errors.Is(err, c.err) {
+					t.Errorf("expected %v, got %v", c.err, err)
+				}
+			case c.wantErr:
+				if err == nil {
+					t.Error("expected an error")
+				} else if status.Code(err) == codes.Unavailable || status.Code(err) == codes.DeadlineExceeded {
+					// the etcd client would retry it again
+					t.Errorf("expected a final error, got %v", err)
+				}
+			case err != nil:
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
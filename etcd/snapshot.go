@@ -0,0 +1,193 @@
+//
+// snapshot.go
+// Copyright (C) 2021 rmelo <Ricardo Melo <rmelo@ludia.com>>
+//
+// Distributed under terms of the MIT license.
+//
+
+package etcd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// snapshotStatus describes a snapshot file, as `etcdutl snapshot status`.
+type snapshotStatus struct {
+	Hash      uint32
+	Revision  int64
+	TotalKey  int
+	TotalSize int64
+}
+
+// hasSnapshotChecksum returns true when a snapshot of size n ends with the
+// sha256 digest appended by the etcd server. The server pads the db to a
+// multiple of 512 bytes before appending it.
+func hasSnapshotChecksum(n int64) bool {
+	return (n % 512) == sha256.Size
+}
+
+// saveSnapshot streams a snapshot from the cluster into path, verifying the
+// digest appended by the server before atomically moving it in place. It
+// returns the sha256 and the size of the saved file.
+func saveSnapshot(ctx context.Context, cli *clientv3.Client, path string) (string, int64, error) {
+	partPath := path + ".part"
+	defer os.Remove(partPath)
+
+	f, err := os.OpenFile(partPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return "", 0, fmt.Errorf("could not open %v: %v", partPath, err)
+	}
+	defer f.Close()
+
+	rd, err := cli.Snapshot(ctx)
+	if err != nil {
+		return "", 0, err
+	}
+	defer rd.Close()
+
+	size, err := io.Copy(f, rd)
+	if err != nil {
+		return "", 0, err
+	}
+	if err := f.Sync(); err != nil {
+		return "", 0, err
+	}
+	if err := f.Close(); err != nil {
+		return "", 0, err
+	}
+	if err := verifySnapshotChecksum(partPath); err != nil {
+		return "", 0, err
+	}
+	sum, err := fileSHA256(partPath)
+	if err != nil {
+		return "", 0, err
+	}
+	if err := os.Rename(partPath, path); err != nil {
+		return "", 0, fmt.Errorf("could not rename %v to %v: %v", partPath, path, err)
+	}
+
+	return sum, size, nil
+}
+
+// verifySnapshotChecksum checks the sha256 digest appended to a snapshot
+// against its content.
+func verifySnapshotChecksum(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if !hasSnapshotChecksum(info.Size()) {
+		return fmt.Errorf("snapshot %v has no sha256 checksum [bytes: %d]", path, info.Size())
+	}
+
+	h := sha256.New()
+	if _, err := io.CopyN(h, f, info.Size()-sha256.Size); err != nil {
+		return err
+	}
+	expected := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(f, expected); err != nil {
+		return err
+	}
+	if !bytes.Equal(h.Sum(nil), expected) {
+		return fmt.Errorf("snapshot %v is corrupted, its sha256 checksum does not match", path)
+	}
+
+	return nil
+}
+
+// fileSHA256 returns the hexadecimal sha256 of a file.
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// readSnapshotStatus opens a snapshot file and computes its status the same
+// way `etcdutl snapshot status` does. Files with a broken checksum or failing
+// the bolt integrity check are reported as corrupted.
+func readSnapshotStatus(path string) (*snapshotStatus, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if hasSnapshotChecksum(info.Size()) {
+		if err := verifySnapshotChecksum(path); err != nil {
+			return nil, err
+		}
+	}
+
+	db, err := bolt.Open(path, 0400, &bolt.Options{ReadOnly: true, Timeout: 10 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("snapshot %v is not a valid etcd db: %v", path, err)
+	}
+	defer db.Close()
+
+	status := &snapshotStatus{}
+	h := crc32.New(crc32.MakeTable(crc32.Castagnoli))
+
+	err = db.View(func(tx *bolt.Tx) error {
+		var checkErrs []string
+		for checkErr := range tx.Check() {
+			checkErrs = append(checkErrs, checkErr.Error())
+		}
+		if len(checkErrs) > 0 {
+			return fmt.Errorf("snapshot %v failed the integrity check, %d errors found:\n%v", path, len(checkErrs), strings.Join(checkErrs, "\n"))
+		}
+
+		status.TotalSize = tx.Size()
+		c := tx.Cursor()
+		for next, _ := c.First(); next != nil; next, _ = c.Next() {
+			b := tx.Bucket(next)
+			if b == nil {
+				return fmt.Errorf("cannot get hash of bucket %v", string(next))
+			}
+			h.Write(next)
+			isKeyBucket := string(next) == "key"
+			b.ForEach(func(k, v []byte) error {
+				h.Write(k)
+				h.Write(v)
+				if isKeyBucket && len(k) >= 8 {
+					// keys of the "key" bucket start with the main revision
+					status.Revision = int64(binary.BigEndian.Uint64(k[0:8]))
+				}
+				status.TotalKey++
+				return nil
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	status.Hash = h.Sum32()
+
+	return status, nil
+}
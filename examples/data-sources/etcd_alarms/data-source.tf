@@ -0,0 +1,2 @@
+data "etcd_alarms" "active" {
+}
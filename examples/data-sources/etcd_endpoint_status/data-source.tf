@@ -0,0 +1,6 @@
+data "etcd_endpoint_status" "cluster" {
+}
+
+output "leader" {
+  value = data.etcd_endpoint_status.cluster.leader_endpoint
+}
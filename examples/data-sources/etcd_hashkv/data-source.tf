@@ -0,0 +1,3 @@
+data "etcd_hashkv" "check" {
+  fail_on_mismatch = true
+}
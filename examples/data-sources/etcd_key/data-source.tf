@@ -1,3 +1,8 @@
 data "etcd_key" "name" {
   key = "/root/path/name"
 }
+
+data "etcd_key" "override" {
+  key     = "/root/path/override"
+  default = "disabled"
+}
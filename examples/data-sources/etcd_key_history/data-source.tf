@@ -0,0 +1,4 @@
+data "etcd_key_history" "version" {
+  key   = "/app/config/version"
+  limit = 5
+}
@@ -0,0 +1,9 @@
+data "etcd_key_wait" "db_ready" {
+  key       = "/services/db/ready"
+  condition = "equals"
+  expected  = "true"
+
+  timeouts {
+    read = "10m"
+  }
+}
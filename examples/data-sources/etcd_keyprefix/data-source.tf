@@ -1,3 +1,22 @@
 data "etcd_keyprefix" "all" {
     prefix = "/"
 }
+
+data "etcd_keyprefix" "latest" {
+    prefix     = "/releases/"
+    sort_by    = "MOD"
+    sort_order = "DESCEND"
+    limit      = 5
+}
+
+data "etcd_keyprefix" "app" {
+    prefix       = "/app/"
+    strip_prefix = true
+}
+
+locals {
+  # /app/db/host
+  db_host = jsondecode(data.etcd_keyprefix.app.tree_json).db.host
+  # /app/db/port
+  db_port = data.etcd_keyprefix.app.values["db/port"]
+}
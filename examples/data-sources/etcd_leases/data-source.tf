@@ -0,0 +1,3 @@
+data "etcd_leases" "services" {
+  key_prefix = "/services/"
+}
@@ -0,0 +1,6 @@
+data "etcd_members" "cluster" {
+}
+
+output "client_urls" {
+  value = flatten(data.etcd_members.cluster.members[*].client_urls)
+}
@@ -0,0 +1,4 @@
+data "etcd_prefix_changes" "since_release" {
+  prefix         = "/prod/"
+  since_revision = var.release_revision
+}
@@ -0,0 +1,7 @@
+# /app/db/host = "db.example.com" is rendered as DB_HOST="db.example.com"
+data "etcd_prefix_export" "app_env" {
+  prefix            = "/app/"
+  format            = "dotenv"
+  replace_separator = "_"
+  uppercase         = true
+}
@@ -0,0 +1,3 @@
+data "etcd_prefix_stats" "team" {
+  prefix = "/teams/payments/"
+}
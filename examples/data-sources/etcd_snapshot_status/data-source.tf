@@ -0,0 +1,3 @@
+data "etcd_snapshot_status" "backup" {
+  path = "${path.root}/backups/etcd-before-upgrade.db"
+}
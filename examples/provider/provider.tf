@@ -7,4 +7,11 @@ provider "etcd" {
   # you decide skip that you can set tls to false
   # tls           = var.tls         # optionally use ETCD_TLS env var
   # ca_cert       = var.ca_cert     # optionally use ETCD_CACERT env var
+
+  # Every key, prefix and permission becomes relative to this namespace.
+  # namespace     = "/tenant-x/"    # optionally use ETCD_NAMESPACE env var
+
+  # Instead of endpoints, discover them from _etcd-client-ssl._tcp.<domain>.
+  # discovery_srv = "example.com"   # optionally use ETCD_DISCOVERY_SRV env var
+  # discovery_dns_server = "10.0.0.53:53" # optionally use ETCD_DISCOVERY_DNS_SERVER env var
 }
@@ -0,0 +1,8 @@
+resource "etcd_alarm_disarm" "nospace" {
+  alarm = "NOSPACE"
+
+  # Disarm again whenever the compaction revision changes.
+  triggers = {
+    revision = var.compacted_revision
+  }
+}
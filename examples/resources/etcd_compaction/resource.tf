@@ -0,0 +1,8 @@
+resource "etcd_compaction" "daily" {
+  retain_revisions = 10000
+  physical         = true
+
+  triggers = {
+    day = formatdate("YYYY-MM-DD", timestamp())
+  }
+}
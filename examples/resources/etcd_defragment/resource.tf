@@ -0,0 +1,5 @@
+resource "etcd_defragment" "after_compaction" {
+  triggers = {
+    compacted_revision = etcd_compaction.daily.compacted_revision
+  }
+}
@@ -0,0 +1,4 @@
+# Keep the leadership away from etcd1 while it is being drained.
+resource "etcd_leader" "leader" {
+  member_name = "etcd2"
+}
@@ -0,0 +1,4 @@
+resource "etcd_member" "etcd4" {
+  peer_urls  = ["https://etcd4.example.com:2380"]
+  is_learner = false
+}
@@ -0,0 +1,8 @@
+resource "etcd_snapshot" "before_upgrade" {
+  path = "${path.root}/backups/etcd-before-upgrade.db"
+
+  # Take a new snapshot whenever the release changes.
+  triggers = {
+    release = var.release
+  }
+}